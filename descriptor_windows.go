@@ -0,0 +1,492 @@
+package hid
+
+import (
+	"bytes"
+	"cmp"
+	"encoding/binary"
+	"errors"
+	"slices"
+	"unsafe"
+
+	"github.com/telesma-app/hid/reportparser"
+)
+
+// ReportDescriptor returns a report descriptor reconstructed from the
+// preparsed data of the device, because Windows does not return the original
+// to user mode. It declares the same collections and report fields, but its
+// items can differ from those of the device.
+func (d *Device) ReportDescriptor() ([]byte, error) {
+	if err := d.beginOperation(); err != nil {
+		return nil, err
+	}
+	defer d.operations.Done()
+
+	preparsedData, err := getPreparsedData(d.hFile)
+	if err != nil {
+		return nil, d.ioError(err)
+	}
+	defer func() {
+		_ = freePreparsedData(preparsedData)
+	}()
+
+	return reconstructReportDescriptor(preparsedDataBytes(preparsedData, preparsedDataSize(preparsedData)))
+}
+
+// The layout of the preparsed data of HidD_GetPreparsedData is undocumented.
+// The offsets below are those that hidapi reconstructs descriptors from.
+const (
+	preparsedHeaderSize   = 44
+	preparsedCapSize      = 104
+	preparsedLinkNodeSize = 16
+)
+
+var (
+	preparsedMagic = []byte("HidP KDR")
+
+	errInvalidPreparsedData = errors.New("invalid HID preparsed data")
+)
+
+const (
+	preparsedCapIsMultipleItemsForArray = 1 << iota
+	preparsedCapIsPadding
+	preparsedCapIsButton
+	preparsedCapIsAbsolute
+	preparsedCapIsRange
+	preparsedCapIsAlias
+	preparsedCapIsStringRange
+	preparsedCapIsDesignatorRange
+)
+
+// preparsedCap describes one main item, or one usage of an array main item
+// with several usages.
+type preparsedCap struct {
+	reportType     int
+	reportID       uint8
+	usagePage      uint16
+	start          int
+	reportSize     uint16
+	reportCount    uint16
+	mainFlags      uint32
+	linkCollection uint16
+	flags          uint8
+
+	usageMin, usageMax           uint16
+	stringMin, stringMax         uint16
+	designatorMin, designatorMax uint16
+
+	logicalMin, logicalMax   int32
+	physicalMin, physicalMax int32
+	units, unitsExp          uint32
+}
+
+func (c *preparsedCap) end() int {
+	return c.start + int(c.reportSize)*int(c.reportCount)
+}
+
+type preparsedLinkNode struct {
+	usage, usagePage uint16
+	parent           uint16
+	collectionType   uint8
+}
+
+// preparsedDataSize returns the size of the preparsed data at p from the
+// counts in its header.
+func preparsedDataSize(p _PHIDP_PREPARSED_DATA) int {
+	header := preparsedDataBytes(p, preparsedHeaderSize)
+	if !bytes.Equal(header[:len(preparsedMagic)], preparsedMagic) {
+		return preparsedHeaderSize
+	}
+
+	size := preparsedHeaderSize
+	for reportType := range 3 {
+		lastCap := int(binary.LittleEndian.Uint16(header[16+8*reportType+4:]))
+		size = max(size, preparsedHeaderSize+lastCap*preparsedCapSize)
+	}
+	linkOffset := preparsedHeaderSize + int(binary.LittleEndian.Uint16(header[40:]))
+	linkNodes := int(binary.LittleEndian.Uint16(header[42:]))
+	return max(size, linkOffset+linkNodes*preparsedLinkNodeSize)
+}
+
+// preparsedDataBytes returns the first n bytes of the preparsed data at p,
+// which hid.dll allocates outside of the Go heap.
+func preparsedDataBytes(p _PHIDP_PREPARSED_DATA, n int) []byte {
+	return unsafe.Slice((*byte)(*(*unsafe.Pointer)(unsafe.Pointer(&p))), n)
+}
+
+func parsePreparsedData(b []byte) ([]*preparsedCap, []preparsedLinkNode, error) {
+	if len(b) < preparsedHeaderSize || !bytes.Equal(b[:len(preparsedMagic)], preparsedMagic) {
+		return nil, nil, errInvalidPreparsedData
+	}
+
+	var caps []*preparsedCap
+	for reportType := range 3 {
+		info := b[16+8*reportType:]
+		firstCap := int(binary.LittleEndian.Uint16(info))
+		lastCap := int(binary.LittleEndian.Uint16(info[4:]))
+		for i := firstCap; i < lastCap; i++ {
+			offset := preparsedHeaderSize + i*preparsedCapSize
+			if offset+preparsedCapSize > len(b) {
+				return nil, nil, errInvalidPreparsedData
+			}
+			caps = append(caps, parsePreparsedCap(reportType, b[offset:offset+preparsedCapSize]))
+		}
+	}
+
+	linkOffset := preparsedHeaderSize + int(binary.LittleEndian.Uint16(b[40:]))
+	linkNodes := int(binary.LittleEndian.Uint16(b[42:]))
+	if linkNodes == 0 || linkOffset+linkNodes*preparsedLinkNodeSize > len(b) {
+		return nil, nil, errInvalidPreparsedData
+	}
+	nodes := make([]preparsedLinkNode, linkNodes)
+	for i := range nodes {
+		node := b[linkOffset+i*preparsedLinkNodeSize:]
+		nodes[i] = preparsedLinkNode{
+			usage:          binary.LittleEndian.Uint16(node),
+			usagePage:      binary.LittleEndian.Uint16(node[2:]),
+			parent:         binary.LittleEndian.Uint16(node[4:]),
+			collectionType: node[12],
+		}
+	}
+	for _, c := range caps {
+		if int(c.linkCollection) >= len(nodes) {
+			return nil, nil, errInvalidPreparsedData
+		}
+	}
+
+	return caps, nodes, nil
+}
+
+func parsePreparsedCap(reportType int, b []byte) *preparsedCap {
+	le := binary.LittleEndian
+	c := &preparsedCap{
+		reportType:     reportType,
+		usagePage:      le.Uint16(b),
+		reportID:       b[2],
+		start:          int(le.Uint16(b[8:]))*8 + int(b[3]),
+		reportSize:     le.Uint16(b[4:]),
+		reportCount:    le.Uint16(b[6:]),
+		mainFlags:      le.Uint32(b[12:]),
+		linkCollection: le.Uint16(b[18:]),
+		flags:          b[24],
+		usageMin:       le.Uint16(b[60:]),
+		usageMax:       le.Uint16(b[62:]),
+		stringMin:      le.Uint16(b[64:]),
+		stringMax:      le.Uint16(b[66:]),
+		designatorMin:  le.Uint16(b[68:]),
+		designatorMax:  le.Uint16(b[70:]),
+	}
+	if c.flags&preparsedCapIsButton != 0 {
+		c.logicalMin = int32(le.Uint32(b[76:]))
+		c.logicalMax = int32(le.Uint32(b[80:]))
+	} else {
+		c.logicalMin = int32(le.Uint32(b[80:]))
+		c.logicalMax = int32(le.Uint32(b[84:]))
+		c.physicalMin = int32(le.Uint32(b[88:]))
+		c.physicalMax = int32(le.Uint32(b[92:]))
+		c.units = le.Uint32(b[96:])
+		c.unitsExp = le.Uint32(b[100:])
+	}
+	return c
+}
+
+// preparsedMainItem is a main item of the reconstructed descriptor. Caps
+// holds its usages; a padding item has none.
+type preparsedMainItem struct {
+	reportType int
+	reportID   uint8
+	start, end int
+	caps       []*preparsedCap
+	collection uint16
+}
+
+type preparsedReportKey struct {
+	reportType int
+	reportID   uint8
+}
+
+// reconstructReportDescriptor returns a report descriptor that declares the
+// same collections and report fields as the preparsed data b. Bits that no
+// field covers become constant padding. Alias usages are not reconstructed.
+func reconstructReportDescriptor(b []byte) ([]byte, error) {
+	caps, nodes, err := parsePreparsedData(b)
+	if err != nil {
+		return nil, err
+	}
+
+	reports := make(map[preparsedReportKey][]*preparsedCap)
+	var keys []preparsedReportKey
+	for _, c := range caps {
+		if c.flags&(preparsedCapIsAlias|preparsedCapIsPadding) != 0 {
+			continue
+		}
+		key := preparsedReportKey{c.reportType, c.reportID}
+		if _, ok := reports[key]; !ok {
+			keys = append(keys, key)
+		}
+		reports[key] = append(reports[key], c)
+	}
+
+	var mainItems []*preparsedMainItem
+	for _, key := range keys {
+		reportCaps := reports[key]
+		slices.SortStableFunc(reportCaps, func(a, b *preparsedCap) int {
+			return cmp.Compare(a.start, b.start)
+		})
+
+		// The first byte holds the report ID, even for unnumbered reports.
+		position := 8
+		var last *preparsedMainItem
+		for _, c := range reportCaps {
+			if last != nil && c.start == last.start {
+				// An array with several usages has one cap per usage.
+				last.caps = append(last.caps, c)
+				continue
+			}
+			if c.start > position {
+				mainItems = append(mainItems, &preparsedMainItem{
+					reportType: key.reportType,
+					reportID:   key.reportID,
+					start:      position,
+					end:        c.start,
+					collection: c.linkCollection,
+				})
+			}
+			last = &preparsedMainItem{
+				reportType: key.reportType,
+				reportID:   key.reportID,
+				start:      c.start,
+				end:        c.end(),
+				caps:       []*preparsedCap{c},
+				collection: c.linkCollection,
+			}
+			mainItems = append(mainItems, last)
+			position = max(position, last.end)
+		}
+		if last != nil && position%8 != 0 {
+			mainItems = append(mainItems, &preparsedMainItem{
+				reportType: key.reportType,
+				reportID:   key.reportID,
+				start:      position,
+				end:        position + 8 - position%8,
+				collection: last.collection,
+			})
+		}
+	}
+
+	w := &preparsedDescriptorWriter{
+		nodes:     nodes,
+		mainItems: make(map[uint16][]*preparsedMainItem),
+		globals:   make(map[reportparser.ItemTag]any),
+	}
+	for _, item := range mainItems {
+		w.mainItems[item.collection] = append(w.mainItems[item.collection], item)
+		if item.reportID != 0 {
+			w.numbered = true
+		}
+	}
+	w.starts = make([]map[preparsedReportKey]int, len(nodes))
+	w.collection(0)
+
+	return w.items.Bytes()
+}
+
+type preparsedDescriptorWriter struct {
+	nodes     []preparsedLinkNode
+	mainItems map[uint16][]*preparsedMainItem
+	numbered  bool
+
+	// starts caches the first bit of each report within a collection.
+	starts  []map[preparsedReportKey]int
+	items   reportparser.Items
+	globals map[reportparser.ItemTag]any
+}
+
+// preparsedMember is a main item or a child collection of a collection.
+type preparsedMember struct {
+	item   *preparsedMainItem
+	node   uint16
+	starts map[preparsedReportKey]int
+}
+
+func (w *preparsedDescriptorWriter) collection(node uint16) {
+	n := w.nodes[node]
+	w.global(reportparser.UsagePage(n.usagePage))
+	w.items = append(w.items,
+		reportparser.Usage(n.usage),
+		reportparser.Collection(n.collectionType),
+	)
+
+	for _, member := range w.members(node) {
+		if member.item != nil {
+			w.mainItem(member.item)
+		} else {
+			w.collection(member.node)
+		}
+	}
+
+	w.items = append(w.items, reportparser.EndCollection{})
+}
+
+// members returns the main items and child collections of node in an order
+// that keeps the fields of every report in bit order.
+func (w *preparsedDescriptorWriter) members(node uint16) []preparsedMember {
+	var members []preparsedMember
+	for _, item := range w.mainItems[node] {
+		members = append(members, preparsedMember{
+			item:   item,
+			starts: map[preparsedReportKey]int{{item.reportType, item.reportID}: item.start},
+		})
+	}
+	for child := int(node) + 1; child < len(w.nodes); child++ {
+		if w.nodes[child].parent == node {
+			members = append(members, preparsedMember{
+				node:   uint16(child),
+				starts: w.collectionStarts(uint16(child)),
+			})
+		}
+	}
+
+	slices.SortStableFunc(members, func(a, b preparsedMember) int {
+		aKeys, bKeys := sortedReportKeys(a.starts), sortedReportKeys(b.starts)
+		for _, key := range aKeys {
+			if bStart, ok := b.starts[key]; ok {
+				return cmp.Compare(a.starts[key], bStart)
+			}
+		}
+		if len(aKeys) == 0 || len(bKeys) == 0 {
+			return 0
+		}
+		return compareReportKeys(aKeys[0], bKeys[0])
+	})
+	return members
+}
+
+func (w *preparsedDescriptorWriter) collectionStarts(node uint16) map[preparsedReportKey]int {
+	if w.starts[node] != nil {
+		return w.starts[node]
+	}
+
+	starts := make(map[preparsedReportKey]int)
+	add := func(key preparsedReportKey, start int) {
+		if s, ok := starts[key]; !ok || start < s {
+			starts[key] = start
+		}
+	}
+	for _, item := range w.mainItems[node] {
+		add(preparsedReportKey{item.reportType, item.reportID}, item.start)
+	}
+	for child := int(node) + 1; child < len(w.nodes); child++ {
+		if w.nodes[child].parent == node {
+			for key, start := range w.collectionStarts(uint16(child)) {
+				add(key, start)
+			}
+		}
+	}
+	w.starts[node] = starts
+	return starts
+}
+
+func sortedReportKeys(starts map[preparsedReportKey]int) []preparsedReportKey {
+	keys := make([]preparsedReportKey, 0, len(starts))
+	for key := range starts {
+		keys = append(keys, key)
+	}
+	slices.SortFunc(keys, compareReportKeys)
+	return keys
+}
+
+func compareReportKeys(a, b preparsedReportKey) int {
+	return cmp.Or(cmp.Compare(a.reportType, b.reportType), cmp.Compare(a.reportID, b.reportID))
+}
+
+// global appends a global item unless it is already in effect.
+func (w *preparsedDescriptorWriter) global(item interface{ Tag() reportparser.ItemTag }) {
+	if last, ok := w.globals[item.Tag()]; ok && last == item {
+		return
+	}
+	w.globals[item.Tag()] = item
+	w.items = append(w.items, item)
+}
+
+func (w *preparsedDescriptorWriter) mainItem(item *preparsedMainItem) {
+	if w.numbered {
+		w.global(reportparser.ReportID(item.reportID))
+	}
+
+	var flags uint32
+	if len(item.caps) == 0 {
+		// A Report Size may not exceed 32 bits, so wider padding is written
+		// as single bits.
+		size, count := item.end-item.start, 1
+		if size > 32 {
+			size, count = 1, size
+		}
+		w.global(reportparser.ReportSize(size))
+		w.global(reportparser.ReportCount(count))
+		flags = uint32(reportparser.InputFlagConstant)
+	} else {
+		c := item.caps[0]
+		w.global(reportparser.UsagePage(c.usagePage))
+		w.global(reportparser.LogicalMinimum(c.logicalMin))
+		w.global(reportparser.LogicalMaximum(c.logicalMax))
+		if c.flags&preparsedCapIsButton == 0 {
+			w.global(reportparser.PhysicalMinimum(c.physicalMin))
+			w.global(reportparser.PhysicalMaximum(c.physicalMax))
+			w.global(reportparser.Unit(c.units))
+			w.global(reportparser.UnitExponent(preparsedUnitExponent(c.unitsExp)))
+		}
+		w.global(reportparser.ReportSize(c.reportSize))
+		w.global(reportparser.ReportCount(c.reportCount))
+		for _, usageCap := range item.caps {
+			w.usages(c.usagePage, usageCap)
+		}
+		flags = c.mainFlags
+	}
+
+	switch item.reportType {
+	case 0:
+		w.items = append(w.items, reportparser.Input(flags))
+	case 1:
+		w.items = append(w.items, reportparser.Output(flags))
+	default:
+		w.items = append(w.items, reportparser.Feature(flags))
+	}
+}
+
+// usages appends the local items of c. Usages on another page than the
+// main item's are extended usages.
+func (w *preparsedDescriptorWriter) usages(usagePage uint16, c *preparsedCap) {
+	usage := func(id uint16) uint32 {
+		if c.usagePage != usagePage {
+			return uint32(c.usagePage)<<16 | uint32(id)
+		}
+		return uint32(id)
+	}
+
+	if c.flags&preparsedCapIsRange != 0 {
+		w.items = append(w.items,
+			reportparser.UsageMinimum(usage(c.usageMin)),
+			reportparser.UsageMaximum(usage(c.usageMax)),
+		)
+	} else {
+		w.items = append(w.items, reportparser.Usage(usage(c.usageMin)))
+	}
+	if c.flags&preparsedCapIsStringRange != 0 {
+		w.items = append(w.items, reportparser.StringMinimum(c.stringMin), reportparser.StringMaximum(c.stringMax))
+	} else if c.stringMin != 0 {
+		w.items = append(w.items, reportparser.StringIndex(c.stringMin))
+	}
+	if c.flags&preparsedCapIsDesignatorRange != 0 {
+		w.items = append(w.items, reportparser.DesignatorMinimum(c.designatorMin), reportparser.DesignatorMaximum(c.designatorMax))
+	} else if c.designatorMin != 0 {
+		w.items = append(w.items, reportparser.DesignatorIndex(c.designatorMin))
+	}
+}
+
+// preparsedUnitExponent converts the 4-bit exponent of the preparsed data.
+func preparsedUnitExponent(exp uint32) int32 {
+	if exp >= 8 && exp <= 15 {
+		return int32(exp) - 16
+	}
+	return int32(exp)
+}
@@ -0,0 +1,311 @@
+package hid
+
+import (
+	"encoding/binary"
+	"reflect"
+	"testing"
+
+	"github.com/telesma-app/hid/reportparser"
+)
+
+// preparsedDataFor builds the preparsed data that the Windows HID parser
+// produces for descriptor: one cap per data item, split per usage where the
+// usages are not a range, and link collections in declaration order.
+func preparsedDataFor(t *testing.T, descriptor []byte) []byte {
+	t.Helper()
+
+	d, err := reportparser.Parse(descriptor)
+	if err != nil {
+		t.Fatal(err)
+	}
+	l, err := d.Layout()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var nodes [][preparsedLinkNodeSize]byte
+	indices := make(map[*reportparser.CollectionNode]uint16)
+	var addNode func(node *reportparser.CollectionNode, parent uint16)
+	addNode = func(node *reportparser.CollectionNode, parent uint16) {
+		index := uint16(len(nodes))
+		indices[node] = index
+		var b [preparsedLinkNodeSize]byte
+		binary.LittleEndian.PutUint16(b[0:], node.Usage)
+		binary.LittleEndian.PutUint16(b[2:], node.UsagePage)
+		binary.LittleEndian.PutUint16(b[4:], parent)
+		b[12] = byte(node.Type)
+		nodes = append(nodes, b)
+		for _, child := range node.Collections {
+			addNode(child, index)
+		}
+	}
+	for _, node := range d.Collections {
+		addNode(node, 0)
+	}
+
+	var caps [3][][preparsedCapSize]byte
+	for _, report := range l.Reports {
+		reportType := int(report.Type)
+		for _, field := range report.Fields {
+			item := field.Item
+			if item.IsConstant() {
+				continue
+			}
+			newCap := func(start, count int) []byte {
+				var b [preparsedCapSize]byte
+				binary.LittleEndian.PutUint16(b[0:], item.UsagePage)
+				b[2] = item.ReportID
+				b[3] = byte(start % 8)
+				binary.LittleEndian.PutUint16(b[4:], uint16(item.ReportSize))
+				binary.LittleEndian.PutUint16(b[6:], uint16(count))
+				binary.LittleEndian.PutUint16(b[8:], uint16(start/8))
+				binary.LittleEndian.PutUint32(b[12:], item.Flags)
+				binary.LittleEndian.PutUint16(b[18:], indices[item.Collection])
+				isButton := item.ReportSize == 1 || !item.IsVariable()
+				if isButton {
+					b[24] |= preparsedCapIsButton
+					binary.LittleEndian.PutUint32(b[76:], uint32(item.LogicalMinimum))
+					binary.LittleEndian.PutUint32(b[80:], uint32(item.LogicalMaximum))
+				} else {
+					binary.LittleEndian.PutUint32(b[80:], uint32(item.LogicalMinimum))
+					binary.LittleEndian.PutUint32(b[84:], uint32(item.LogicalMaximum))
+					binary.LittleEndian.PutUint32(b[88:], uint32(item.PhysicalMinimum))
+					binary.LittleEndian.PutUint32(b[92:], uint32(item.PhysicalMaximum))
+					binary.LittleEndian.PutUint32(b[96:], uint32(item.Unit))
+					binary.LittleEndian.PutUint32(b[100:], uint32(item.UnitExponent)&0xf)
+				}
+				caps[reportType] = append(caps[reportType], b)
+				return caps[reportType][len(caps[reportType])-1][:]
+			}
+
+			usages := item.Usages
+			start := field.BitOffset + 8
+			switch {
+			case isUsageRange(usages):
+				b := newCap(start, int(item.ReportCount))
+				b[24] |= preparsedCapIsRange
+				setUsagePage(b, usages[0])
+				binary.LittleEndian.PutUint16(b[60:], usages[0].ID())
+				binary.LittleEndian.PutUint16(b[62:], usages[len(usages)-1].ID())
+			case !item.IsVariable():
+				for i, usage := range usages {
+					b := newCap(start, int(item.ReportCount))
+					setUsagePage(b, usage)
+					binary.LittleEndian.PutUint16(b[60:], usage.ID())
+					if i < len(usages)-1 {
+						b[24] |= preparsedCapIsMultipleItemsForArray
+					}
+				}
+			default:
+				for i, usage := range usages {
+					count := 1
+					if i == len(usages)-1 {
+						count = int(item.ReportCount) - i
+					}
+					b := newCap(start+i*int(item.ReportSize), count)
+					setUsagePage(b, usage)
+					binary.LittleEndian.PutUint16(b[60:], usage.ID())
+				}
+			}
+		}
+	}
+
+	b := make([]byte, preparsedHeaderSize)
+	copy(b, preparsedMagic)
+	capCount := 0
+	for reportType, typeCaps := range caps {
+		info := b[16+8*reportType:]
+		binary.LittleEndian.PutUint16(info[0:], uint16(capCount))
+		binary.LittleEndian.PutUint16(info[2:], uint16(len(typeCaps)))
+		capCount += len(typeCaps)
+		binary.LittleEndian.PutUint16(info[4:], uint16(capCount))
+		for _, c := range typeCaps {
+			b = append(b, c[:]...)
+		}
+	}
+	binary.LittleEndian.PutUint16(b[40:], uint16(capCount*preparsedCapSize))
+	binary.LittleEndian.PutUint16(b[42:], uint16(len(nodes)))
+	for _, node := range nodes {
+		b = append(b, node[:]...)
+	}
+	return b
+}
+
+func isUsageRange(usages []reportparser.ExtendedUsage) bool {
+	if len(usages) < 2 {
+		return len(usages) == 1
+	}
+	for i := 1; i < len(usages); i++ {
+		if usages[i] != usages[i-1]+1 {
+			return false
+		}
+	}
+	return true
+}
+
+func setUsagePage(b []byte, usage reportparser.ExtendedUsage) {
+	binary.LittleEndian.PutUint16(b[0:], usage.Page())
+}
+
+// dataFields summarizes the non-constant fields of a layout.
+type dataField struct {
+	Type           reportparser.ReportType
+	ID             uint8
+	BitOffset      int
+	ReportSize     uint32
+	ReportCount    uint32
+	Flags          uint32
+	Usages         []reportparser.ExtendedUsage
+	LogicalMinimum int32
+	LogicalMaximum int32
+	Unit           reportparser.Unit
+	Collection     [2]uint16
+}
+
+func dataFields(t *testing.T, descriptor []byte) (fields []dataField, byteLengths map[[2]int]int) {
+	t.Helper()
+
+	d, err := reportparser.Parse(descriptor)
+	if err != nil {
+		t.Fatal(err)
+	}
+	l, err := d.Layout()
+	if err != nil {
+		t.Fatal(err)
+	}
+	byteLengths = make(map[[2]int]int)
+	for _, report := range l.Reports {
+		byteLengths[[2]int{int(report.Type), int(report.ID)}] = report.ByteLength()
+		for _, field := range report.Fields {
+			item := field.Item
+			if item.IsConstant() {
+				continue
+			}
+			// Split variable items into one field per usage, as the caps are.
+			usages := item.Usages
+			if !item.IsVariable() || isUsageRange(usages) {
+				fields = append(fields, dataField{
+					report.Type, report.ID, field.BitOffset, item.ReportSize, item.ReportCount, item.Flags, usages,
+					item.LogicalMinimum, item.LogicalMaximum, item.Unit,
+					[2]uint16{item.Collection.UsagePage, item.Collection.Usage},
+				})
+				continue
+			}
+			for i, usage := range usages {
+				count := uint32(1)
+				if i == len(usages)-1 {
+					count = item.ReportCount - uint32(i)
+				}
+				fields = append(fields, dataField{
+					report.Type, report.ID, field.BitOffset + i*int(item.ReportSize), item.ReportSize, count, item.Flags,
+					[]reportparser.ExtendedUsage{usage},
+					item.LogicalMinimum, item.LogicalMaximum, item.Unit,
+					[2]uint16{item.Collection.UsagePage, item.Collection.Usage},
+				})
+			}
+		}
+	}
+	return fields, byteLengths
+}
+
+func TestReconstructReportDescriptor(t *testing.T) {
+	tests := []struct {
+		name       string
+		descriptor []byte
+	}{
+		{
+			name: "keyboard",
+			descriptor: []byte{
+				0x05, 0x01, 0x09, 0x06, 0xa1, 0x01, // Generic Desktop, Keyboard, Application
+				0x05, 0x07, 0x19, 0xe0, 0x29, 0xe7, // Keyboard/Keypad, LeftControl..Right GUI
+				0x15, 0x00, 0x25, 0x01, 0x75, 0x01, 0x95, 0x08, 0x81, 0x02, // Input (Variable)
+				0x95, 0x01, 0x75, 0x08, 0x81, 0x01, // Input (Constant)
+				0x05, 0x08, 0x19, 0x01, 0x29, 0x05, // LED, Num Lock..Kana
+				0x95, 0x05, 0x75, 0x01, 0x91, 0x02, // Output (Variable)
+				0x95, 0x01, 0x75, 0x03, 0x91, 0x01, // Output (Constant)
+				0x05, 0x07, 0x19, 0x00, 0x29, 0x65, // Keyboard/Keypad, 0..0x65
+				0x15, 0x00, 0x25, 0x65, 0x95, 0x06, 0x75, 0x08, 0x81, 0x00, // Input (Array)
+				0xc0,
+			},
+		},
+		{
+			name: "mouse with feature report",
+			descriptor: []byte{
+				0x05, 0x01, 0x09, 0x02, 0xa1, 0x01, // Generic Desktop, Mouse, Application
+				0x85, 0x01, 0x09, 0x01, 0xa1, 0x00, // Report ID 1, Pointer, Physical
+				0x05, 0x09, 0x19, 0x01, 0x29, 0x03, // Button 1..3
+				0x15, 0x00, 0x25, 0x01, 0x95, 0x03, 0x75, 0x01, 0x81, 0x02,
+				0x95, 0x01, 0x75, 0x05, 0x81, 0x03,
+				0x05, 0x01, 0x09, 0x30, 0x09, 0x31, // X, Y
+				0x15, 0x81, 0x25, 0x7f, 0x75, 0x08, 0x95, 0x02, 0x81, 0x06,
+				0xc0,
+				0x09, 0x38, 0x95, 0x01, 0x81, 0x06, // Wheel after the physical collection
+				0x85, 0x02, 0x09, 0x48, // Report ID 2, Resolution Multiplier
+				0x15, 0x00, 0x25, 0x01, 0x35, 0x01, 0x45, 0x04, 0x75, 0x02, 0x95, 0x01, 0xb1, 0x02,
+				0x75, 0x06, 0xb1, 0x01,
+				0xc0,
+			},
+		},
+		{
+			name: "consumer array with listed usages",
+			descriptor: []byte{
+				0x05, 0x0c, 0x09, 0x01, 0xa1, 0x01, // Consumer, Consumer Control, Application
+				0x09, 0xe9, 0x09, 0xea, 0x09, 0xe2, // Volume Increment, Volume Decrement, Mute
+				0x15, 0x01, 0x25, 0x03, 0x75, 0x02, 0x95, 0x01, 0x81, 0x00,
+				0x09, 0xcd, 0x09, 0xb5, 0x15, 0x00, 0x25, 0x01, 0x75, 0x01, 0x95, 0x03, 0x81, 0x02,
+				0x95, 0x03, 0x81, 0x03,
+				0xc0,
+			},
+		},
+		{
+			// The 40-bit gap does not fit in one Report Size. dataFields
+			// fails unless the reconstructed descriptor has a layout.
+			name: "padding wider than 32 bits",
+			descriptor: []byte{
+				0x06, 0x00, 0xff, 0x09, 0x01, 0xa1, 0x01, // Vendor 0xFF00, Application
+				0x09, 0x02, 0x15, 0x00, 0x26, 0xff, 0x00, 0x75, 0x08, 0x95, 0x01, 0x81, 0x02,
+				0x95, 0x05, 0x81, 0x01, // Input (Constant), 40 bits
+				0x09, 0x03, 0x95, 0x01, 0x81, 0x02,
+				0xc0,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := reconstructReportDescriptor(preparsedDataFor(t, test.descriptor))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diagnostics := reportparser.Validate(got); len(diagnostics) > 0 {
+				for _, diagnostic := range diagnostics {
+					if diagnostic.Severity == reportparser.SeverityError {
+						t.Errorf("reconstructed descriptor: %v", diagnostic)
+					}
+				}
+			}
+
+			wantFields, wantLengths := dataFields(t, test.descriptor)
+			gotFields, gotLengths := dataFields(t, got)
+			if !reflect.DeepEqual(gotFields, wantFields) {
+				text, _ := reportparser.ParseReport(got).MarshalText()
+				t.Fatalf("fields = %+v\nwant %+v\ndescriptor:\n%s", gotFields, wantFields, text)
+			}
+			if !reflect.DeepEqual(gotLengths, wantLengths) {
+				t.Fatalf("report byte lengths = %v, want %v", gotLengths, wantLengths)
+			}
+		})
+	}
+}
+
+func TestReconstructReportDescriptorInvalid(t *testing.T) {
+	for _, b := range [][]byte{
+		nil,
+		[]byte("HidP KDR"),
+		make([]byte, preparsedHeaderSize),
+	} {
+		if _, err := reconstructReportDescriptor(b); err != errInvalidPreparsedData {
+			t.Errorf("reconstructReportDescriptor(%q) error = %v, want errInvalidPreparsedData", b, err)
+		}
+	}
+}
@@ -0,0 +1,56 @@
+package hid
+
+import "sync"
+
+// Done returns a channel that is closed when the device can no longer be
+// used, either because it was removed or because Close was called. Err then
+// returns the reason.
+func (d *Device) Done() <-chan struct{} {
+	return d.done.channel()
+}
+
+// Err returns nil until Done is closed. After that it returns
+// ErrDisconnected if the device was removed, or ErrClosed if it was closed
+// first.
+func (d *Device) Err() error {
+	return d.done.error()
+}
+
+// deviceDone records why a device ended. The zero value is an open device.
+type deviceDone struct {
+	mu   sync.Mutex
+	done chan struct{}
+	err  error
+}
+
+func (d *deviceDone) channel() <-chan struct{} {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.done == nil {
+		d.done = make(chan struct{})
+	}
+	return d.done
+}
+
+func (d *deviceDone) error() error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	return d.err
+}
+
+// finish ends the device with err unless it has already ended.
+func (d *deviceDone) finish(err error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.err != nil {
+		return
+	}
+	d.err = err
+	if d.done == nil {
+		d.done = make(chan struct{})
+	}
+	close(d.done)
+}
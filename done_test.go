@@ -0,0 +1,46 @@
+package hid
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestDeviceDone(t *testing.T) {
+	var d deviceDone
+	done := d.channel()
+
+	select {
+	case <-done:
+		t.Fatal("done is closed before finish")
+	default:
+	}
+	if err := d.error(); err != nil {
+		t.Fatalf("error() = %v before finish, want nil", err)
+	}
+
+	d.finish(ErrDisconnected)
+	d.finish(ErrClosed)
+
+	select {
+	case <-done:
+	default:
+		t.Fatal("done is open after finish")
+	}
+	if err := d.error(); !errors.Is(err, ErrDisconnected) {
+		t.Fatalf("error() = %v, want ErrDisconnected", err)
+	}
+}
+
+func TestDeviceDoneFinishBeforeChannel(t *testing.T) {
+	var d deviceDone
+	d.finish(ErrClosed)
+
+	select {
+	case <-d.channel():
+	default:
+		t.Fatal("done is open after finish")
+	}
+	if err := d.error(); !errors.Is(err, ErrClosed) {
+		t.Fatalf("error() = %v, want ErrClosed", err)
+	}
+}
@@ -0,0 +1,47 @@
+package hid
+
+import "errors"
+
+// The errors of OpenPath and Device wrap one of these sentinels when it
+// applies, along with the native error of the platform, so that errors.Is
+// matches either of them.
+var (
+	// ErrDeviceNotFound is returned by OpenPath when no device has the path.
+	ErrDeviceNotFound = errors.New("device not found")
+	// ErrDisconnected is returned when the device was unplugged or removed.
+	ErrDisconnected = errors.New("device disconnected")
+	// ErrPermission is returned when the process may not open or use the
+	// device. The errors also match os.ErrPermission.
+	ErrPermission = errors.New("permission denied")
+	// ErrClosed is returned by the methods of a closed Device, including a
+	// Read or Write that Close interrupts.
+	ErrClosed = errors.New("device closed")
+	// ErrReportTooLarge is returned when a report exceeds the report length
+	// of the device or the limit of the platform.
+	ErrReportTooLarge = errors.New("report too large")
+)
+
+// deviceError is a native error that errors.Is also matches against kind,
+// one of the sentinel errors.
+type deviceError struct {
+	kind error
+	err  error
+}
+
+func (e *deviceError) Error() string {
+	return e.err.Error()
+}
+
+func (e *deviceError) Unwrap() []error {
+	return []error{e.kind, e.err}
+}
+
+// wrapError wraps err with the sentinel kind. It returns err unchanged when
+// either is nil or err already matches kind.
+func wrapError(kind, err error) error {
+	if kind == nil || err == nil || errors.Is(err, kind) {
+		return err
+	}
+
+	return &deviceError{kind: kind, err: err}
+}
@@ -0,0 +1,34 @@
+package hid
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestWrapError(t *testing.T) {
+	native := errors.New("native error")
+
+	err := wrapError(ErrDisconnected, native)
+	if !errors.Is(err, ErrDisconnected) {
+		t.Errorf("errors.Is(%v, ErrDisconnected) = false", err)
+	}
+	if !errors.Is(err, native) {
+		t.Errorf("errors.Is(%v, native) = false", err)
+	}
+	if errors.Is(err, ErrPermission) {
+		t.Errorf("errors.Is(%v, ErrPermission) = true", err)
+	}
+	if err.Error() != native.Error() {
+		t.Errorf("Error() = %q, want %q", err.Error(), native.Error())
+	}
+
+	if err := wrapError(nil, native); err != native {
+		t.Errorf("wrapError(nil, native) = %v, want native", err)
+	}
+	if err := wrapError(ErrDisconnected, nil); err != nil {
+		t.Errorf("wrapError(ErrDisconnected, nil) = %v, want nil", err)
+	}
+	if err := wrapError(ErrClosed, ErrClosed); err != ErrClosed {
+		t.Errorf("wrapError(ErrClosed, ErrClosed) = %v, want ErrClosed", err)
+	}
+}
@@ -13,6 +13,17 @@ func TestIOReturnPermissionError(t *testing.T) {
 	if !errors.Is(err, os.ErrPermission) {
 		t.Fatalf("errors.Is(%v, os.ErrPermission) = false", err)
 	}
+	if !errors.Is(err, ErrPermission) {
+		t.Fatalf("errors.Is(%v, ErrPermission) = false", err)
+	}
+}
+
+func TestIOReturnDisconnectedError(t *testing.T) {
+	for _, result := range []ioReturn{kIOReturnNoDevice, kIOReturnNotAttached} {
+		if err := ioReturnError("IOHIDDeviceSetReport", result); !errors.Is(err, ErrDisconnected) {
+			t.Errorf("errors.Is(%v, ErrDisconnected) = false", err)
+		}
+	}
 }
 
 func TestEventLifecycle(t *testing.T) {
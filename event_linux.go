@@ -5,7 +5,6 @@ import (
 	"errors"
 	"fmt"
 	"os"
-	"path/filepath"
 	"strings"
 	"sync"
 
@@ -30,6 +29,7 @@ type linuxEventReceiver struct {
 	wakeFD   int
 	stopped  chan struct{}
 
+	backend        *LinuxBackend
 	loadDeviceInfo func(string) (*DeviceInfo, error)
 
 	mu            sync.Mutex
@@ -272,16 +272,12 @@ func isLinuxHIDRawName(name string) bool {
 	return true
 }
 
-func linuxHIDRawPath(name string) string {
-	return filepath.Join(linuxDeviceDir, name)
-}
-
 func (er *linuxEventReceiver) onUevent(uevent linuxUevent) {
 	if !isLinuxHIDRawName(uevent.device) ||
 		(uevent.eventType != DeviceEventConnected && uevent.eventType != DeviceEventDisconnected) {
 		return
 	}
-	path := linuxHIDRawPath(uevent.device)
+	path := er.backend.hidrawPath(uevent.device)
 
 	er.mu.Lock()
 	if er.closed {
@@ -415,8 +411,8 @@ func (er *linuxEventReceiver) publishStartup(snapshot []DeviceEvent) {
 	er.initializing = false
 }
 
-func linuxInitialEventSnapshot() ([]DeviceEvent, error) {
-	names, err := linuxHIDRawNames()
+func (b *LinuxBackend) initialEventSnapshot(sysfs bool) ([]DeviceEvent, error) {
+	names, err := b.hidrawNames(sysfs)
 	if errors.Is(err, os.ErrNotExist) {
 		return nil, nil
 	}
@@ -430,8 +426,8 @@ func linuxInitialEventSnapshot() ([]DeviceEvent, error) {
 			continue
 		}
 
-		path := linuxHIDRawPath(name)
-		info, infoErr := getLinuxDeviceInfo(name)
+		path := b.hidrawPath(name)
+		info, infoErr := b.deviceInfo(name, sysfs)
 		if info == nil {
 			info = &DeviceInfo{Path: path}
 		} else {
@@ -486,23 +482,36 @@ func openLinuxUeventSocket() (socketFD int, wakeFD int, err error) {
 // Watch captures the current HID snapshot and then publishes later connection
 // and removal events.
 func Watch() (Watcher, error) {
+	return defaultLinuxBackend.Watch()
+}
+
+// Watch is like the package function Watch but reads the snapshot and
+// device metadata from the backend's directories. Connection events still
+// come from the kernel of the calling process.
+func (b *LinuxBackend) Watch() (Watcher, error) {
 	socketFD, wakeFD, err := openLinuxUeventSocket()
 	if err != nil {
 		return nil, err
 	}
 
+	// The sysfs check holds for the lifetime of the watcher.
+	sysfs := b.hasSysfs()
+	loadDeviceInfo := func(name string) (*DeviceInfo, error) {
+		return b.deviceInfo(name, sysfs)
+	}
 	receiver := &linuxEventReceiver{
 		events:         newDeviceEventQueue(),
 		socketFD:       socketFD,
 		wakeFD:         wakeFD,
 		stopped:        make(chan struct{}),
-		loadDeviceInfo: getLinuxDeviceInfo,
+		backend:        b,
+		loadDeviceInfo: loadDeviceInfo,
 		initializing:   true,
 		devices:        make(map[string]*DeviceInfo),
 	}
 	go receiver.run()
 
-	snapshot, err := linuxInitialEventSnapshot()
+	snapshot, err := b.initialEventSnapshot(sysfs)
 	if err != nil {
 		closeErr := receiver.Close()
 		return nil, errors.Join(fmt.Errorf("enumerate initial HID snapshot: %w", err), closeErr)
@@ -3,6 +3,7 @@ package hid
 import (
 	"errors"
 	"os"
+	"slices"
 	"strings"
 	"sync"
 	"testing"
@@ -387,7 +388,12 @@ func TestLinuxEventReceiverReportsBackgroundReadFailure(t *testing.T) {
 
 func linuxIntegrationWatcher(t *testing.T) Watcher {
 	t.Helper()
-	receiver, err := Watch()
+	return linuxBackendIntegrationWatcher(t, defaultLinuxBackend)
+}
+
+func linuxBackendIntegrationWatcher(t *testing.T, backend *LinuxBackend) Watcher {
+	t.Helper()
+	receiver, err := backend.Watch()
 	if err != nil {
 		if errors.Is(err, unix.EPERM) || errors.Is(err, unix.EACCES) ||
 			errors.Is(err, unix.EPROTONOSUPPORT) || errors.Is(err, unix.EAFNOSUPPORT) {
@@ -430,7 +436,7 @@ func TestWatchCapturesInitialSnapshot(t *testing.T) {
 	receiver := linuxIntegrationWatcher(t)
 	defer receiver.Close()
 
-	names, err := linuxHIDRawNames()
+	names, err := defaultLinuxBackend.hidrawNames(defaultLinuxBackend.hasSysfs())
 	if errors.Is(err, os.ErrNotExist) {
 		return
 	}
@@ -440,7 +446,7 @@ func TestWatchCapturesInitialSnapshot(t *testing.T) {
 	expected := make(map[string]struct{}, len(names))
 	for _, name := range names {
 		if isLinuxHIDRawName(name) {
-			expected[linuxHIDRawPath(name)] = struct{}{}
+			expected[defaultLinuxBackend.hidrawPath(name)] = struct{}{}
 		}
 	}
 	seen := make(map[string]struct{}, len(expected))
@@ -460,6 +466,27 @@ func TestWatchCapturesInitialSnapshot(t *testing.T) {
 	}
 }
 
+func TestLinuxBackendWatchSnapshot(t *testing.T) {
+	receiver := linuxBackendIntegrationWatcher(t, &LinuxBackend{
+		SysfsDir:  linuxSysfsFixture(t),
+		DeviceDir: "/run/hid/dev",
+	})
+	defer receiver.Close()
+
+	var paths []string
+	for _, device := range receiver.Snapshot().Devices {
+		if device.MetadataErr != nil {
+			t.Fatalf("metadata for %s: %v", device.DeviceInfo.Path, device.MetadataErr)
+		}
+		paths = append(paths, device.DeviceInfo.Path)
+	}
+	slices.Sort(paths)
+	want := []string{"/run/hid/dev/hidraw0", "/run/hid/dev/hidraw1", "/run/hid/dev/hidraw2"}
+	if !slices.Equal(paths, want) {
+		t.Fatalf("snapshot paths = %q, want %q", paths, want)
+	}
+}
+
 func waitForLinuxEvent(t *testing.T, events <-chan DeviceEvent, eventType DeviceEventType, hint string, timeout time.Duration) DeviceEvent {
 	t.Helper()
 	hint = strings.ToLower(hint)
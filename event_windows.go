@@ -19,8 +19,11 @@ type (
 
 const (
 	_CM_NOTIFY_FILTER_TYPE_DEVICEINTERFACE   _CM_NOTIFY_FILTER_TYPE = 0x00000000
+	_CM_NOTIFY_FILTER_TYPE_DEVICEHANDLE      _CM_NOTIFY_FILTER_TYPE = 0x00000001
 	_CM_NOTIFY_ACTION_DEVICEINTERFACEARRIVAL _CM_NOTIFY_ACTION      = 0x00000000
 	_CM_NOTIFY_ACTION_DEVICEINTERFACEREMOVAL _CM_NOTIFY_ACTION      = 0x00000001
+	_CM_NOTIFY_ACTION_DEVICEREMOVEPENDING    _CM_NOTIFY_ACTION      = 0x00000004
+	_CM_NOTIFY_ACTION_DEVICEREMOVECOMPLETE   _CM_NOTIFY_ACTION      = 0x00000005
 	_MAX_DEVICE_ID_LEN                                              = 200
 )
 
@@ -45,9 +48,12 @@ var (
 	procCMRegisterNotification   = modCfgMgr32.NewProc("CM_Register_Notification")
 	procCMUnregisterNotification = modCfgMgr32.NewProc("CM_Unregister_Notification")
 	cmCallback                   = syscall.NewCallback(cmNotificationCallback)
+	cmDeviceCallback             = syscall.NewCallback(cmDeviceNotificationCallback)
 
 	cmReceivers   sync.Map
 	cmReceiverSeq atomic.Uint64
+	cmDevices     sync.Map
+	cmDeviceSeq   atomic.Uint64
 )
 
 func cmRegisterNotification(
@@ -213,6 +219,33 @@ func cmNotificationCallback(
 	)
 }
 
+// cmDeviceNotificationCallback receives the notifications of the handle of an
+// open Device and ends the device when it is removed.
+func cmDeviceNotificationCallback(
+	hNotify uintptr,
+	context uintptr,
+	action _CM_NOTIFY_ACTION,
+	eventData unsafe.Pointer,
+	eventDataSize uintptr,
+) uintptr {
+	if action != _CM_NOTIFY_ACTION_DEVICEREMOVEPENDING && action != _CM_NOTIFY_ACTION_DEVICEREMOVECOMPLETE {
+		return 0
+	}
+
+	v, ok := cmDevices.Load(context)
+	if !ok {
+		return 0
+	}
+
+	d, ok := v.(*Device)
+	if !ok {
+		return 0
+	}
+	d.done.finish(ErrDisconnected)
+
+	return 0
+}
+
 func (er *cmEventReceiver) Listen() <-chan DeviceEvent {
 	return er.events.Listen()
 }
@@ -0,0 +1,41 @@
+package hid
+
+import (
+	"bytes"
+	"context"
+)
+
+// SendFeatureReportContext is SendFeatureReport with a context. It returns
+// ctx.Err() as soon as ctx is done, but the native call cannot be stopped:
+// when it has already started, the report may still be delivered after the
+// method returns. Calls of SendFeatureReportContext and
+// GetFeatureReportContext are serialized and wait for such a call to end. A
+// call canceled while it waits leaves nothing behind, so a wedged device ties
+// up at most one native call.
+func (d *Device) SendFeatureReportContext(ctx context.Context, report []byte) error {
+	report = bytes.Clone(report)
+	result := runIO(ctx, &d.featureMu, func() ioResult {
+		return ioResult{err: d.SendFeatureReport(report)}
+	})
+
+	return result.err
+}
+
+// GetFeatureReportContext is GetFeatureReport with a context. It returns
+// ctx.Err() as soon as ctx is done and then leaves report unchanged, although
+// the request may still reach the device. It is serialized like
+// SendFeatureReportContext.
+func (d *Device) GetFeatureReportContext(ctx context.Context, report []byte) (int, error) {
+	buffer := bytes.Clone(report)
+	result := runIO(ctx, &d.featureMu, func() ioResult {
+		n, err := d.GetFeatureReport(buffer)
+
+		return ioResult{n: n, err: err}
+	})
+	if result.err != nil {
+		return 0, result.err
+	}
+	copy(report, buffer)
+
+	return result.n, nil
+}
@@ -3,7 +3,15 @@
 package hid
 
 import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
 	"testing"
+	"time"
+
+	"golang.org/x/sys/unix"
 )
 
 func TestHIDIOCFeature(t *testing.T) {
@@ -13,4 +21,100 @@ func TestHIDIOCFeature(t *testing.T) {
 	if got, want := hidIOCFeature(0x07, 65), uintptr(0xc0414807); got != want {
 		t.Errorf("hidIOCFeature(0x07, 65) = %#x, want %#x", got, want)
 	}
+	if got, want := hidIOCFeature(0x0a, 65), uintptr(0xc041480a); got != want {
+		t.Errorf("hidIOCFeature(0x0a, 65) = %#x, want %#x", got, want)
+	}
+	if got, want := hidIOCFeature(0x0b, 65), uintptr(0xc041480b); got != want {
+		t.Errorf("hidIOCFeature(0x0b, 65) = %#x, want %#x", got, want)
+	}
+}
+
+func TestRawIoctlsOnNonHIDRawFile(t *testing.T) {
+	file, err := os.Create(filepath.Join(t.TempDir(), "hidraw0"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	device := &Device{file: file}
+	defer device.Close()
+
+	if _, err := device.RawInfo(); !errors.Is(err, unix.ENOTTY) {
+		t.Errorf("RawInfo() error = %v, want ENOTTY", err)
+	}
+	for name, ioctl := range map[string]func() (string, error){
+		"RawName": device.RawName,
+		"RawPhys": device.RawPhys,
+		"RawUniq": device.RawUniq,
+	} {
+		if _, err := ioctl(); !errors.Is(err, unix.ENOTTY) {
+			t.Errorf("%s() error = %v, want ENOTTY", name, err)
+		}
+	}
+	if _, err := device.ReportDescriptor(); !errors.Is(err, unix.ENOTTY) {
+		t.Errorf("ReportDescriptor() error = %v, want ENOTTY", err)
+	}
+	if err := device.SendOutputReport([]byte{0x00, 0x01}); !errors.Is(err, unix.ENOTTY) {
+		t.Errorf("SendOutputReport() error = %v, want ENOTTY", err)
+	}
+	if _, err := device.GetInputReport(make([]byte, 2)); !errors.Is(err, unix.ENOTTY) {
+		t.Errorf("GetInputReport() error = %v, want ENOTTY", err)
+	}
+}
+
+func TestFeatureReportContext(t *testing.T) {
+	file, err := os.Create(filepath.Join(t.TempDir(), "hidraw0"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	device := &Device{file: file}
+	defer device.Close()
+
+	if err := device.SendFeatureReportContext(context.Background(), []byte{0x01, 0x02}); !errors.Is(err, unix.ENOTTY) {
+		t.Errorf("SendFeatureReportContext() error = %v, want ENOTTY", err)
+	}
+	report := []byte{0x01, 0x00}
+	if _, err := device.GetFeatureReportContext(context.Background(), report); !errors.Is(err, unix.ENOTTY) {
+		t.Errorf("GetFeatureReportContext() error = %v, want ENOTTY", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := device.SendFeatureReportContext(ctx, []byte{0x01, 0x02}); !errors.Is(err, context.Canceled) {
+		t.Errorf("SendFeatureReportContext() error = %v, want context.Canceled", err)
+	}
+}
+
+func TestFeatureReportContextReturnsWhileSerialized(t *testing.T) {
+	file, err := os.Create(filepath.Join(t.TempDir(), "hidraw0"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	device := &Device{file: file}
+	defer device.Close()
+
+	// A wedged call holds the lock until the device answers.
+	if err := device.featureMu.lock(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	defer device.featureMu.unlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	report := []byte{0x01, 0xaa}
+	done := make(chan error, 1)
+	go func() {
+		_, err := device.GetFeatureReportContext(ctx, report)
+		done <- err
+	}()
+
+	select {
+	case err := <-done:
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Fatalf("GetFeatureReportContext() error = %v, want context.DeadlineExceeded", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("GetFeatureReportContext() did not return at the context deadline")
+	}
+	if !bytes.Equal(report, []byte{0x01, 0xaa}) {
+		t.Fatalf("report = %v, want it unchanged", report)
+	}
 }
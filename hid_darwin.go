@@ -28,6 +28,9 @@ var (
 	cfDictionaryCreate        func(cfAllocatorRef, uintptr, uintptr, cfIndex, uintptr, uintptr) cfDictionaryRef
 	cfGetTypeID               func(cfTypeRef) uintptr
 	cfStringGetTypeID         func() uintptr
+	cfDataGetTypeID           func() uintptr
+	cfDataGetLength           func(cfTypeRef) cfIndex
+	cfDataGetBytePtr          func(cfTypeRef) *byte
 	cfSetGetCount             func(cfSetRef) cfIndex
 	cfSetGetValues            func(cfSetRef, uintptr)
 	cfRunLoopGetCurrent       func() uintptr
@@ -64,8 +67,6 @@ var (
 	deviceSeq                atomic.Uint64
 )
 
-var errDarwinDeviceRemoved = errors.New("HID device removed")
-
 type darwinWriteRequest struct {
 	report []byte
 	result chan ioResult
@@ -92,6 +93,9 @@ func init() {
 	purego.RegisterLibFunc(&cfDictionaryCreate, coreFoundation, "CFDictionaryCreate")
 	purego.RegisterLibFunc(&cfGetTypeID, coreFoundation, "CFGetTypeID")
 	purego.RegisterLibFunc(&cfStringGetTypeID, coreFoundation, "CFStringGetTypeID")
+	purego.RegisterLibFunc(&cfDataGetTypeID, coreFoundation, "CFDataGetTypeID")
+	purego.RegisterLibFunc(&cfDataGetLength, coreFoundation, "CFDataGetLength")
+	purego.RegisterLibFunc(&cfDataGetBytePtr, coreFoundation, "CFDataGetBytePtr")
 	purego.RegisterLibFunc(&cfSetGetCount, coreFoundation, "CFSetGetCount")
 	purego.RegisterLibFunc(&cfSetGetValues, coreFoundation, "CFSetGetValues")
 	purego.RegisterLibFunc(&cfRunLoopGetCurrent, coreFoundation, "CFRunLoopGetCurrent")
@@ -199,7 +203,7 @@ func OpenPath(path string) (*Device, error) {
 	}
 
 	if opened == nil {
-		return nil, errors.New("device not found")
+		return nil, ErrDeviceNotFound
 	}
 
 	return opened, nil
@@ -214,16 +218,16 @@ func (d *Device) Read(ctx context.Context, p []byte) (int, error) {
 	case <-ctx.Done():
 		return 0, ctx.Err()
 	case <-d.removed:
-		return 0, errDarwinDeviceRemoved
+		return 0, ErrDisconnected
 
 	case report, ok := <-d.reports:
 		if !ok {
 			select {
 			case <-d.removed:
-				return 0, errDarwinDeviceRemoved
+				return 0, ErrDisconnected
 			default:
 			}
-			return 0, errors.New("device closed")
+			return 0, ErrClosed
 		}
 
 		return copy(p, report), nil
@@ -234,6 +238,9 @@ func (d *Device) Write(ctx context.Context, p []byte) (int, error) {
 	if err := ctx.Err(); err != nil {
 		return 0, err
 	}
+	if _, report := prepareIOHIDReport(p); len(report) > d.outputReportByteLength {
+		return 0, fmt.Errorf("%w: %d bytes exceed %d", ErrReportTooLarge, len(report), d.outputReportByteLength)
+	}
 
 	request := darwinWriteRequest{
 		report: bytes.Clone(p),
@@ -243,9 +250,9 @@ func (d *Device) Write(ctx context.Context, p []byte) (int, error) {
 	case <-ctx.Done():
 		return 0, ctx.Err()
 	case <-d.closing:
-		return 0, errors.New("device closed")
+		return 0, ErrClosed
 	case <-d.removed:
-		return 0, errDarwinDeviceRemoved
+		return 0, ErrDisconnected
 	case d.writes <- request:
 	}
 
@@ -253,9 +260,9 @@ func (d *Device) Write(ctx context.Context, p []byte) (int, error) {
 	case <-ctx.Done():
 		return 0, ctx.Err()
 	case <-d.closing:
-		return 0, errors.New("device closed")
+		return 0, ErrClosed
 	case <-d.removed:
-		return 0, errDarwinDeviceRemoved
+		return 0, ErrDisconnected
 	case result := <-request.result:
 		return result.n, result.err
 	}
@@ -275,10 +282,10 @@ func (d *Device) runWrites() {
 		case request := <-d.writes:
 			select {
 			case <-d.closing:
-				request.result <- ioResult{err: errors.New("device closed")}
+				request.result <- ioResult{err: ErrClosed}
 				return
 			case <-d.removed:
-				request.result <- ioResult{err: errDarwinDeviceRemoved}
+				request.result <- ioResult{err: ErrDisconnected}
 				return
 			default:
 			}
@@ -302,11 +309,36 @@ func (d *Device) runWrites() {
 }
 
 func (d *Device) SendFeatureReport(report []byte) error {
+	return d.setReport(kIOHIDReportTypeFeature, report)
+}
+
+func (d *Device) GetFeatureReport(report []byte) (int, error) {
+	return d.getReport(kIOHIDReportTypeFeature, report)
+}
+
+// SendOutputReport sends an output report with IOHIDDeviceSetReport, which
+// uses the control pipe of USB devices.
+func (d *Device) SendOutputReport(report []byte) error {
+	return d.setReport(kIOHIDReportTypeOutput, report)
+}
+
+// GetInputReport requests an input report with IOHIDDeviceGetReport. The
+// first byte of report selects the report ID.
+func (d *Device) GetInputReport(report []byte) (int, error) {
+	return d.getReport(kIOHIDReportTypeInput, report)
+}
+
+func (d *Device) setReport(reportType ioHIDReportType, report []byte) error {
+	if err := d.beginOperation(); err != nil {
+		return err
+	}
+	defer d.operations.Done()
+
 	reportID, data := prepareIOHIDReport(report)
 
 	ret := ioHIDDeviceSetReport(
 		d.device,
-		kIOHIDReportTypeFeature,
+		reportType,
 		reportID,
 		data,
 		cfIndex(len(data)),
@@ -318,13 +350,18 @@ func (d *Device) SendFeatureReport(report []byte) error {
 	return nil
 }
 
-func (d *Device) GetFeatureReport(report []byte) (int, error) {
+func (d *Device) getReport(reportType ioHIDReportType, report []byte) (int, error) {
+	if err := d.beginOperation(); err != nil {
+		return 0, err
+	}
+	defer d.operations.Done()
+
 	reportID, data := prepareIOHIDReport(report)
 	length := cfIndex(len(data))
 
 	ret := ioHIDDeviceGetReport(
 		d.device,
-		kIOHIDReportTypeFeature,
+		reportType,
 		reportID,
 		data,
 		&length,
@@ -340,6 +377,21 @@ func (d *Device) GetFeatureReport(report []byte) (int, error) {
 	return n, nil
 }
 
+// ReportDescriptor returns the report descriptor of the device from its
+// ReportDescriptor property.
+func (d *Device) ReportDescriptor() ([]byte, error) {
+	if err := d.beginOperation(); err != nil {
+		return nil, err
+	}
+	defer d.operations.Done()
+
+	descriptor := dataProperty(d.device, "ReportDescriptor")
+	if descriptor == nil {
+		return nil, errors.New("HID device has no report descriptor")
+	}
+	return descriptor, nil
+}
+
 func prepareIOHIDReport(report []byte) (cfIndex, []byte) {
 	reportID := cfIndex(0)
 	if len(report) > 0 {
@@ -363,6 +415,10 @@ func prepareOutputReport(p []byte, reportByteLength int) (cfIndex, []byte) {
 	return reportID, report
 }
 
+// Close closes the device. It wakes Read and Write, which return ErrClosed,
+// aborts the pending report calls, and releases the device once the calls
+// that use it have returned. If a call does not return, Close returns after a
+// few seconds and the device is released when the call returns.
 func (d *Device) Close() error {
 	d.closeMu.Lock()
 	if d.closed {
@@ -371,18 +427,40 @@ func (d *Device) Close() error {
 	}
 	d.closed = true
 	close(d.closing)
+	d.done.finish(ErrClosed)
 	if d.runLoop != 0 {
 		cfRunLoopStop(d.runLoop)
 	}
 	d.closeMu.Unlock()
 
 	<-d.stopped
-	<-d.writeStopped
-	unregisterDevice(d.cbID)
+	// Closing the device aborts the report calls that are still pending, so
+	// that the wait below does not depend on the device answering them.
 	_ = ioHIDDeviceClose(d.device, 0)
-	cfRelease(cfTypeRef(d.device))
-	runtime.KeepAlive(d.inputReportBuffer)
-	d.inputReportBufferPin.Unpin()
+	releaseWhenIdle(func() {
+		<-d.writeStopped
+		d.operations.Wait()
+	}, func() {
+		unregisterDevice(d.cbID)
+		cfRelease(cfTypeRef(d.device))
+		runtime.KeepAlive(d.inputReportBuffer)
+		d.inputReportBufferPin.Unpin()
+	})
+	return nil
+}
+
+// beginOperation registers an operation that uses the device reference,
+// which Close waits for before it releases the reference. The operation calls
+// operations.Done when it no longer uses the device.
+func (d *Device) beginOperation() error {
+	d.closeMu.Lock()
+	defer d.closeMu.Unlock()
+
+	if d.closed {
+		return ErrClosed
+	}
+	d.operations.Add(1)
+
 	return nil
 }
 
@@ -432,8 +510,11 @@ runLoop:
 }
 
 func ioReturnError(operation string, result ioReturn) error {
-	if result == kIOReturnNotPermitted || result == kIOReturnNotPrivileged {
-		return fmt.Errorf("%w: %s failed: 0x%08x", os.ErrPermission, operation, uint32(result))
+	switch result {
+	case kIOReturnNotPermitted, kIOReturnNotPrivileged:
+		return wrapError(ErrPermission, fmt.Errorf("%w: %s failed: 0x%08x", os.ErrPermission, operation, uint32(result)))
+	case kIOReturnNoDevice, kIOReturnNotAttached:
+		return wrapError(ErrDisconnected, fmt.Errorf("%s failed: 0x%08x", operation, uint32(result)))
 	}
 	return fmt.Errorf("%s failed: 0x%08x", operation, uint32(result))
 }
@@ -465,6 +546,7 @@ func deviceRemovalCallback(context uintptr, _ ioReturn, _ uintptr) {
 	d.removeOnce.Do(func() {
 		close(d.removed)
 	})
+	d.done.finish(ErrDisconnected)
 
 	d.closeMu.Lock()
 	if d.runLoop != 0 {
@@ -655,9 +737,26 @@ func getDeviceInfo(device ioHIDDeviceRef) (*DeviceInfo, error) {
 		UsagePage:  uint16(intProperty(device, "PrimaryUsagePage")),
 		Usage:      uint16(intProperty(device, "PrimaryUsage")),
 		InstanceID: strconv.FormatUint(entryID, 16),
+		BusType:    darwinBusType(stringProperty(device, "Transport")),
 	}, nil
 }
 
+// darwinBusType maps the kIOHIDTransport*Value strings of IOHIDKeys.h.
+func darwinBusType(transport string) BusType {
+	switch transport {
+	case "USB":
+		return BusUSB
+	case "Bluetooth", "BluetoothLowEnergy":
+		return BusBluetooth
+	case "I2C":
+		return BusI2C
+	case "SPI":
+		return BusSPI
+	default:
+		return ""
+	}
+}
+
 func registryEntryID(device ioHIDDeviceRef) (uint64, error) {
 	service := ioHIDDeviceGetService(device)
 	if service == 0 {
@@ -698,6 +797,17 @@ func stringProperty(device ioHIDDeviceRef, key string) string {
 	return cfStringToString(cfStringRef(value))
 }
 
+func dataProperty(device ioHIDDeviceRef, key string) []byte {
+	cfKey := cfString(key)
+	defer cfRelease(cfTypeRef(cfKey))
+
+	value := ioHIDDeviceGetProperty(device, cfKey)
+	if value == 0 || cfGetTypeID(value) != cfDataGetTypeID() {
+		return nil
+	}
+	return bytes.Clone(unsafe.Slice(cfDataGetBytePtr(value), cfDataGetLength(value)))
+}
+
 func cfString(s string) cfStringRef {
 	buf := append([]byte(s), 0)
 	return cfStringCreateWithCString(0, uintptr(unsafe.Pointer(unsafe.SliceData(buf))), kCFStringEncodingUTF8)
@@ -8,6 +8,7 @@ import (
 	"slices"
 	"sync/atomic"
 	"testing"
+	"time"
 	"unsafe"
 )
 
@@ -333,6 +334,13 @@ func TestDeviceWriteCancellationLeavesWorkerUsable(t *testing.T) {
 	}
 }
 
+func TestDeviceWriteReportTooLarge(t *testing.T) {
+	device := newDarwinWriteTestDevice(t)
+	if _, err := device.Write(context.Background(), []byte{0, 1, 2, 3, 4, 5}); !errors.Is(err, ErrReportTooLarge) {
+		t.Fatalf("Write() error = %v, want ErrReportTooLarge", err)
+	}
+}
+
 func TestDeviceRemovalUnblocksReadAndWrite(t *testing.T) {
 	original := ioHIDDeviceSetReport
 	t.Cleanup(func() {
@@ -370,11 +378,19 @@ func TestDeviceRemovalUnblocksReadAndWrite(t *testing.T) {
 
 	deviceRemovalCallback(device.cbID, kIOReturnSuccess, 0)
 
-	if err := <-readResult; !errors.Is(err, errDarwinDeviceRemoved) {
-		t.Fatalf("Read() error = %v, want %v", err, errDarwinDeviceRemoved)
+	if err := <-readResult; !errors.Is(err, ErrDisconnected) {
+		t.Fatalf("Read() error = %v, want %v", err, ErrDisconnected)
 	}
-	if err := <-writeResult; !errors.Is(err, errDarwinDeviceRemoved) {
-		t.Fatalf("Write() error = %v, want %v", err, errDarwinDeviceRemoved)
+	if err := <-writeResult; !errors.Is(err, ErrDisconnected) {
+		t.Fatalf("Write() error = %v, want %v", err, ErrDisconnected)
+	}
+	select {
+	case <-device.Done():
+	default:
+		t.Fatal("Done() is open after removal")
+	}
+	if err := device.Err(); !errors.Is(err, ErrDisconnected) {
+		t.Fatalf("Err() = %v, want ErrDisconnected", err)
 	}
 
 	close(releaseWrite)
@@ -391,12 +407,215 @@ func TestDeviceReadPrefersRemovalAfterReportsClose(t *testing.T) {
 	}
 
 	for range 100 {
-		if _, err := device.Read(context.Background(), make([]byte, 64)); !errors.Is(err, errDarwinDeviceRemoved) {
-			t.Fatalf("Read() error = %v, want %v", err, errDarwinDeviceRemoved)
+		if _, err := device.Read(context.Background(), make([]byte, 64)); !errors.Is(err, ErrDisconnected) {
+			t.Fatalf("Read() error = %v, want %v", err, ErrDisconnected)
 		}
 	}
 }
 
+func TestDeviceCloseWaitsForFeatureReport(t *testing.T) {
+	originalSetReport, originalClose, originalRelease := ioHIDDeviceSetReport, ioHIDDeviceClose, cfRelease
+	t.Cleanup(func() {
+		ioHIDDeviceSetReport, ioHIDDeviceClose, cfRelease = originalSetReport, originalClose, originalRelease
+	})
+
+	entered := make(chan struct{})
+	release := make(chan struct{})
+	var released, releasedDuringCall atomic.Bool
+	ioHIDDeviceSetReport = func(_ ioHIDDeviceRef, _ ioHIDReportType, _ cfIndex, _ []byte, _ cfIndex) ioReturn {
+		close(entered)
+		<-release
+		releasedDuringCall.Store(released.Load())
+		return kIOReturnSuccess
+	}
+	ioHIDDeviceClose = func(ioHIDDeviceRef, ioOptionBits) ioReturn {
+		return kIOReturnSuccess
+	}
+	cfRelease = func(cfTypeRef) {
+		released.Store(true)
+	}
+
+	device := newDarwinCloseTestDevice()
+	sendResult := make(chan error, 1)
+	go func() {
+		sendResult <- device.SendFeatureReport([]byte{1, 2})
+	}()
+	<-entered
+
+	closeResult := make(chan error, 1)
+	go func() {
+		closeResult <- device.Close()
+	}()
+	<-device.Done()
+	select {
+	case <-closeResult:
+		t.Fatal("Close() returned during a feature report call")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	close(release)
+	if err := <-sendResult; err != nil {
+		t.Fatalf("SendFeatureReport() error = %v", err)
+	}
+	if err := <-closeResult; err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+	if releasedDuringCall.Load() || !released.Load() {
+		t.Fatalf("device released during call = %v, after Close = %v; want false, true", releasedDuringCall.Load(), released.Load())
+	}
+
+	if err := device.SendFeatureReport([]byte{1, 2}); !errors.Is(err, ErrClosed) {
+		t.Errorf("SendFeatureReport() after Close error = %v, want ErrClosed", err)
+	}
+	if _, err := device.GetFeatureReport(make([]byte, 2)); !errors.Is(err, ErrClosed) {
+		t.Errorf("GetFeatureReport() after Close error = %v, want ErrClosed", err)
+	}
+	if _, err := device.GetInputReport(make([]byte, 2)); !errors.Is(err, ErrClosed) {
+		t.Errorf("GetInputReport() after Close error = %v, want ErrClosed", err)
+	}
+	if _, err := device.ReportDescriptor(); !errors.Is(err, ErrClosed) {
+		t.Errorf("ReportDescriptor() after Close error = %v, want ErrClosed", err)
+	}
+}
+
+func TestDeviceCloseWaitsForAbandonedFeatureReportContext(t *testing.T) {
+	originalGetReport, originalClose, originalRelease := ioHIDDeviceGetReport, ioHIDDeviceClose, cfRelease
+	t.Cleanup(func() {
+		ioHIDDeviceGetReport, ioHIDDeviceClose, cfRelease = originalGetReport, originalClose, originalRelease
+	})
+
+	entered := make(chan struct{})
+	release := make(chan struct{})
+	returned := make(chan struct{})
+	var released, releasedDuringCall atomic.Bool
+	ioHIDDeviceGetReport = func(_ ioHIDDeviceRef, _ ioHIDReportType, _ cfIndex, _ []byte, _ *cfIndex) ioReturn {
+		defer close(returned)
+		close(entered)
+		<-release
+		releasedDuringCall.Store(released.Load())
+		return kIOReturnSuccess
+	}
+	ioHIDDeviceClose = func(ioHIDDeviceRef, ioOptionBits) ioReturn {
+		return kIOReturnSuccess
+	}
+	cfRelease = func(cfTypeRef) {
+		released.Store(true)
+	}
+
+	device := newDarwinCloseTestDevice()
+	ctx, cancel := context.WithCancel(context.Background())
+	getResult := make(chan error, 1)
+	go func() {
+		_, err := device.GetFeatureReportContext(ctx, []byte{1, 0})
+		getResult <- err
+	}()
+	<-entered
+	cancel()
+	if err := <-getResult; !errors.Is(err, context.Canceled) {
+		t.Fatalf("GetFeatureReportContext() error = %v, want context.Canceled", err)
+	}
+
+	// The abandoned native call still uses the device.
+	closeResult := make(chan error, 1)
+	go func() {
+		closeResult <- device.Close()
+	}()
+	<-device.Done()
+	select {
+	case <-closeResult:
+		t.Fatal("Close() returned during an abandoned feature report call")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	close(release)
+	if err := <-closeResult; err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+	<-returned
+	if releasedDuringCall.Load() || !released.Load() {
+		t.Fatalf("device released during call = %v, after Close = %v; want false, true", releasedDuringCall.Load(), released.Load())
+	}
+	if _, err := device.GetFeatureReportContext(context.Background(), []byte{1, 0}); !errors.Is(err, ErrClosed) {
+		t.Errorf("GetFeatureReportContext() after Close error = %v, want ErrClosed", err)
+	}
+}
+
+func TestDeviceCloseReturnsDuringStuckFeatureReport(t *testing.T) {
+	originalGetReport, originalClose, originalRelease, originalTimeout := ioHIDDeviceGetReport, ioHIDDeviceClose, cfRelease, closeTimeout
+	t.Cleanup(func() {
+		ioHIDDeviceGetReport, ioHIDDeviceClose, cfRelease, closeTimeout = originalGetReport, originalClose, originalRelease, originalTimeout
+	})
+	closeTimeout = 50 * time.Millisecond
+
+	entered := make(chan struct{})
+	stuck := make(chan struct{})
+	released := make(chan struct{})
+	var closedDevice atomic.Bool
+	ioHIDDeviceGetReport = func(_ ioHIDDeviceRef, _ ioHIDReportType, _ cfIndex, _ []byte, _ *cfIndex) ioReturn {
+		close(entered)
+		<-stuck
+		return kIOReturnSuccess
+	}
+	ioHIDDeviceClose = func(ioHIDDeviceRef, ioOptionBits) ioReturn {
+		closedDevice.Store(true)
+		return kIOReturnSuccess
+	}
+	cfRelease = func(cfTypeRef) {
+		close(released)
+	}
+
+	device := newDarwinCloseTestDevice()
+	go func() {
+		_, _ = device.GetFeatureReport([]byte{1, 0})
+	}()
+	<-entered
+
+	closeResult := make(chan error, 1)
+	go func() {
+		closeResult <- device.Close()
+	}()
+	select {
+	case err := <-closeResult:
+		if err != nil {
+			t.Fatalf("Close() error = %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Close() did not return during a stuck feature report call")
+	}
+	if !closedDevice.Load() {
+		t.Error("Close() did not close the device to abort the stuck call")
+	}
+	select {
+	case <-released:
+		t.Fatal("device released during a stuck feature report call")
+	default:
+	}
+
+	close(stuck)
+	select {
+	case <-released:
+	case <-time.After(5 * time.Second):
+		t.Fatal("device not released after the stuck call returned")
+	}
+}
+
+// newDarwinCloseTestDevice returns an open device without run loop and write
+// worker, which Close can release through stubbed native calls.
+func newDarwinCloseTestDevice() *Device {
+	stopped := make(chan struct{})
+	close(stopped)
+	writeStopped := make(chan struct{})
+	close(writeStopped)
+
+	return &Device{
+		device:       0x1234,
+		stopped:      stopped,
+		closing:      make(chan struct{}),
+		removed:      make(chan struct{}),
+		writeStopped: writeStopped,
+	}
+}
+
 func newDarwinWriteTestDevice(t *testing.T) *Device {
 	t.Helper()
 
@@ -3,6 +3,7 @@ package hid
 import (
 	"context"
 	"errors"
+	"fmt"
 	"iter"
 	"os"
 	"path/filepath"
@@ -15,12 +16,66 @@ import (
 )
 
 const (
-	linuxHIDRawClassDir = "/sys/class/hidraw"
-	linuxDeviceDir      = "/dev"
+	linuxSysfsDir  = "/sys"
+	linuxDeviceDir = "/dev"
+
+	// hidrawMaxBufferSize is HID_MAX_BUFFER_SIZE, the longest report that
+	// hidraw writes.
+	hidrawMaxBufferSize = 16384
+	// hidIOCMaxLength is the longest report of the report ioctls, whose
+	// request encodes the length in 14 bits.
+	hidIOCMaxLength = 1<<14 - 1
 )
 
-func linuxHIDRawNames() ([]string, error) {
-	dir, err := os.Open(linuxHIDRawClassDir)
+// LinuxBackend enumerates and watches hidraw devices below configurable
+// sysfs and device directories, such as a fixture tree or a /sys that is
+// bind-mounted into a container. Enumerate and Watch use a backend with the
+// default directories; a nil or zero LinuxBackend behaves the same way.
+type LinuxBackend struct {
+	// SysfsDir is the sysfs mount point. It defaults to /sys.
+	SysfsDir string
+	// DeviceDir holds the hidraw device nodes. It defaults to /dev.
+	DeviceDir string
+}
+
+var defaultLinuxBackend = &LinuxBackend{}
+
+func (b *LinuxBackend) sysfsDir() string {
+	if b == nil || b.SysfsDir == "" {
+		return linuxSysfsDir
+	}
+	return b.SysfsDir
+}
+
+func (b *LinuxBackend) deviceDir() string {
+	if b == nil || b.DeviceDir == "" {
+		return linuxDeviceDir
+	}
+	return b.DeviceDir
+}
+
+func (b *LinuxBackend) hidrawClassDir() string {
+	return filepath.Join(b.sysfsDir(), "class", "hidraw")
+}
+
+func (b *LinuxBackend) hidrawPath(name string) string {
+	return filepath.Join(b.deviceDir(), name)
+}
+
+// hasSysfs reports whether the hidraw class directory exists. Without it,
+// devices are found in the device directory and described by ioctls.
+// Enumerate and Watch check it once and pass the result on.
+func (b *LinuxBackend) hasSysfs() bool {
+	_, err := os.Stat(b.hidrawClassDir())
+	return !errors.Is(err, os.ErrNotExist)
+}
+
+func (b *LinuxBackend) hidrawNames(sysfs bool) ([]string, error) {
+	if !sysfs {
+		return b.hidrawDeviceNames()
+	}
+
+	dir, err := os.Open(b.hidrawClassDir())
 	if err != nil {
 		return nil, err
 	}
@@ -31,42 +86,108 @@ func linuxHIDRawNames() ([]string, error) {
 	return dir.Readdirnames(0)
 }
 
-func getLinuxDeviceInfo(name string) (*DeviceInfo, error) {
+// hidrawDeviceNames returns the names of the hidraw nodes in the device
+// directory.
+func (b *LinuxBackend) hidrawDeviceNames() ([]string, error) {
+	entries, err := os.ReadDir(b.deviceDir())
+	if err != nil {
+		return nil, err
+	}
+
+	var names []string
+	for _, entry := range entries {
+		if isLinuxHIDRawName(entry.Name()) {
+			names = append(names, entry.Name())
+		}
+	}
+	return names, nil
+}
+
+// deviceInfo returns the device info of the first top-level application
+// collection.
+func (b *LinuxBackend) deviceInfo(name string, sysfs bool) (*DeviceInfo, error) {
+	infos, err := b.deviceInfos(name, sysfs)
+	return infos[0], err
+}
+
+// deviceInfos returns one device info per top-level application collection,
+// all with the same path. On error, it returns a single, partially filled
+// device info.
+func (b *LinuxBackend) deviceInfos(name string, sysfs bool) ([]*DeviceInfo, error) {
 	name = filepath.Base(name)
-	info := &DeviceInfo{Path: filepath.Join(linuxDeviceDir, name)}
-	sysfsDevicePath := filepath.Join(linuxHIDRawClassDir, name, "device")
+	if !sysfs {
+		return b.deviceInfosFromHIDRaw(name)
+	}
+	info := &DeviceInfo{Path: b.hidrawPath(name)}
+	sysfsDevicePath := filepath.Join(b.hidrawClassDir(), name, "device")
 
 	// Parse usage page and usage from the report descriptor.
 	rawDescriptor, err := os.ReadFile(filepath.Join(sysfsDevicePath, "report_descriptor"))
 	if err != nil {
-		return info, err
+		return []*DeviceInfo{info}, err
 	}
 	fillDeviceInfoUsage(info, rawDescriptor)
 
 	// Parse vendor ID, product ID, product name and serial number from uevent.
 	uevent, err := os.ReadFile(filepath.Join(sysfsDevicePath, "uevent"))
 	if err != nil {
-		return info, err
+		return []*DeviceInfo{info}, err
 	}
 	if err := fillDeviceInfoFromUevent(info, uevent); err != nil {
-		return info, err
+		return []*DeviceInfo{info}, err
+	}
+
+	// Fill the remaining fields from the USB interface and device.
+	if err := fillDeviceInfoFromSysfsParents(info, b.sysfsDir(), sysfsDevicePath); err != nil {
+		return []*DeviceInfo{info}, err
+	}
+
+	return deviceInfoPerCollection(info, rawDescriptor), nil
+}
+
+// deviceInfosFromHIDRaw is deviceInfos for a device directory without
+// sysfs. The hidraw ioctls provide no release number, manufacturer, interface
+// number or device IDs, so these fields remain empty. The bus type is left
+// empty too: a uhid device declares its own bus to HIDIOCGRAWINFO, and only
+// sysfs shows that it is virtual.
+func (b *LinuxBackend) deviceInfosFromHIDRaw(name string) ([]*DeviceInfo, error) {
+	info := &DeviceInfo{Path: b.hidrawPath(name)}
+
+	dev, err := os.OpenFile(info.Path, os.O_RDONLY|unix.O_NONBLOCK, 0)
+	if err != nil {
+		return []*DeviceInfo{info}, err
+	}
+	defer func() {
+		_ = dev.Close()
+	}()
+
+	rawDescriptor, err := fillDeviceInfoFromHIDRaw(info, int(dev.Fd()))
+	if err != nil {
+		return []*DeviceInfo{info}, err
 	}
 
-	return info, nil
+	return deviceInfoPerCollection(info, rawDescriptor), nil
 }
 
 func Enumerate(options ...EnumerateOption) iter.Seq2[*DeviceInfo, error] {
+	return defaultLinuxBackend.Enumerate(options...)
+}
+
+// Enumerate is like the package function Enumerate but reads the
+// backend's directories.
+func (b *LinuxBackend) Enumerate(options ...EnumerateOption) iter.Seq2[*DeviceInfo, error] {
 	opts := newEnumerateOptions(options)
 
 	return func(yield func(*DeviceInfo, error) bool) {
-		names, err := linuxHIDRawNames()
+		sysfs := b.hasSysfs()
+		names, err := b.hidrawNames(sysfs)
 		if err != nil {
 			yield(nil, err)
 			return
 		}
 
 		for _, name := range names {
-			info, err := getLinuxDeviceInfo(name)
+			infos, err := b.deviceInfos(name, sysfs)
 			if err != nil {
 				if !yield(nil, err) {
 					return
@@ -74,15 +195,13 @@ func Enumerate(options ...EnumerateOption) iter.Seq2[*DeviceInfo, error] {
 				continue
 			}
 
-			if info == nil {
-				continue
-			}
-			if !opts.match(info) {
-				continue
-			}
-
-			if !yield(info, nil) {
-				return
+			for _, info := range infos {
+				if !opts.match(info) {
+					continue
+				}
+				if !yield(info, nil) {
+					return
+				}
 			}
 		}
 	}
@@ -101,6 +220,10 @@ func fillDeviceInfoFromUevent(info *DeviceInfo, uevent []byte) error {
 			if len(parts) != 3 {
 				continue
 			}
+			busType, err := strconv.ParseUint(parts[0], 16, 16)
+			if err != nil {
+				return err
+			}
 			vendorID, err := strconv.ParseUint(parts[1], 16, 16)
 			if err != nil {
 				return err
@@ -109,6 +232,7 @@ func fillDeviceInfoFromUevent(info *DeviceInfo, uevent []byte) error {
 			if err != nil {
 				return err
 			}
+			info.BusType = linuxBusType(uint16(busType))
 			info.VendorID = uint16(vendorID)
 			info.ProductID = uint16(productID)
 		case "HID_NAME":
@@ -121,95 +245,347 @@ func fillDeviceInfoFromUevent(info *DeviceInfo, uevent []byte) error {
 	return nil
 }
 
+// fillDeviceInfoFromHIDRaw fills the fields that the hidraw ioctls of fd
+// provide, except the untrusted bus type, and returns the report descriptor.
+func fillDeviceInfoFromHIDRaw(info *DeviceInfo, fd int) ([]byte, error) {
+	rawDescriptor, err := hidrawReportDescriptor(fd)
+	if err != nil {
+		return nil, err
+	}
+	fillDeviceInfoUsage(info, rawDescriptor)
+
+	rawInfo, err := hidrawInfo(fd)
+	if err != nil {
+		return nil, err
+	}
+	info.VendorID = rawInfo.VendorID
+	info.ProductID = rawInfo.ProductID
+
+	if info.ProductStr, err = unix.IoctlHIDGetRawName(fd); err != nil {
+		return nil, err
+	}
+	if info.SerialNbr, err = unix.IoctlHIDGetRawUniq(fd); err != nil {
+		return nil, err
+	}
+
+	return rawDescriptor, nil
+}
+
+func hidrawReportDescriptor(fd int) ([]byte, error) {
+	size, err := unix.IoctlGetInt(fd, unix.HIDIOCGRDESCSIZE)
+	if err != nil {
+		return nil, err
+	}
+	descriptor := unix.HIDRawReportDescriptor{Size: uint32(size)}
+	if err := unix.IoctlHIDGetDesc(fd, &descriptor); err != nil {
+		return nil, err
+	}
+	return append([]byte(nil), descriptor.Value[:descriptor.Size]...), nil
+}
+
+// linuxBusType maps the BUS_* constants of linux/input.h.
+func linuxBusType(bus uint16) BusType {
+	switch bus {
+	case 0x03:
+		return BusUSB
+	case 0x05:
+		return BusBluetooth
+	case 0x06:
+		return BusVirtual
+	case 0x18:
+		return BusI2C
+	case 0x1c:
+		return BusSPI
+	default:
+		return ""
+	}
+}
+
+// fillDeviceInfoFromSysfsParents sets the instance and parent IDs to the
+// sysfs device paths of the HID device and its parent, relative to sysfsDir
+// like the DEVPATH of uevents. When the parent is a USB interface, it also
+// reads the interface number and, from the USB device above it, the release
+// number, manufacturer and, if uevent had none, the serial number.
+//
+// Devices below /devices/virtual, such as uhid devices, have no physical
+// parent. Their bus type is set to BusVirtual whatever bus they declare.
+func fillDeviceInfoFromSysfsParents(info *DeviceInfo, sysfsDir, sysfsDevicePath string) error {
+	root, err := filepath.EvalSymlinks(sysfsDir)
+	if err != nil {
+		return err
+	}
+	hidDir, err := filepath.EvalSymlinks(sysfsDevicePath)
+	if err != nil {
+		return err
+	}
+	interfaceDir := filepath.Dir(hidDir)
+	info.InstanceID = linuxDevpath(root, hidDir)
+	info.ParentDeviceID = linuxDevpath(root, interfaceDir)
+	if strings.HasPrefix(info.InstanceID, "/devices/virtual/") {
+		info.BusType = BusVirtual
+		return nil
+	}
+
+	interfaceNbr, err := readSysfsAttribute(interfaceDir, "bInterfaceNumber")
+	if errors.Is(err, os.ErrNotExist) {
+		// The HID device is not attached to a USB interface.
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	n, err := strconv.ParseUint(interfaceNbr, 16, 8)
+	if err != nil {
+		return err
+	}
+	info.InterfaceNbr = int(n)
+
+	usbDeviceDir := filepath.Dir(interfaceDir)
+	if bcdDevice, err := readSysfsAttribute(usbDeviceDir, "bcdDevice"); err == nil {
+		releaseNbr, err := strconv.ParseUint(bcdDevice, 16, 16)
+		if err != nil {
+			return err
+		}
+		info.ReleaseNbr = uint16(releaseNbr)
+	}
+	if manufacturer, err := readSysfsAttribute(usbDeviceDir, "manufacturer"); err == nil {
+		info.MfrStr = manufacturer
+	}
+	if serial, err := readSysfsAttribute(usbDeviceDir, "serial"); err == nil && info.SerialNbr == "" {
+		info.SerialNbr = serial
+	}
+
+	return nil
+}
+
+func readSysfsAttribute(dir, name string) (string, error) {
+	b, err := os.ReadFile(filepath.Join(dir, name))
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(b)), nil
+}
+
+// linuxDevpath returns dir relative to the sysfs root, starting with a slash.
+func linuxDevpath(root, dir string) string {
+	rel, err := filepath.Rel(root, dir)
+	if err != nil || strings.HasPrefix(rel, "..") {
+		return dir
+	}
+	return "/" + filepath.ToSlash(rel)
+}
+
+// fillDeviceInfoUsage sets the usage of the first top-level application
+// collection.
 func fillDeviceInfoUsage(info *DeviceInfo, rawDescriptor []byte) {
-	for _, item := range reportparser.ParseReport(rawDescriptor) {
+	usage := collectionUsages(rawDescriptor)[0]
+	info.UsagePage = usage.Page()
+	info.Usage = usage.ID()
+}
+
+// deviceInfoPerCollection returns a copy of info for each top-level
+// application collection, as hidapi does.
+func deviceInfoPerCollection(info *DeviceInfo, rawDescriptor []byte) []*DeviceInfo {
+	usages := collectionUsages(rawDescriptor)
+	infos := make([]*DeviceInfo, 0, len(usages))
+	for _, usage := range usages {
+		collectionInfo := *info
+		collectionInfo.UsagePage = usage.Page()
+		collectionInfo.Usage = usage.ID()
+		infos = append(infos, &collectionInfo)
+	}
+	return infos
+}
+
+// collectionUsages returns the usages of the top-level application
+// collections. It returns at least one usage: when the descriptor does not
+// resolve or has no application collection, that is the first Usage Page and
+// Usage it declares, or zero.
+func collectionUsages(rawDescriptor []byte) []reportparser.ExtendedUsage {
+	items := reportparser.ParseReport(rawDescriptor)
+
+	if d, err := items.Descriptor(); err == nil {
+		var usages []reportparser.ExtendedUsage
+		for _, collection := range d.Collections {
+			if collection.Type == reportparser.CollectionItemTypeApplication {
+				usages = append(usages, reportparser.NewExtendedUsage(collection.UsagePage, collection.Usage))
+			}
+		}
+		if len(usages) > 0 {
+			return usages
+		}
+	}
+
+	var usagePage, usage uint16
+	for _, item := range items {
 		switch e := item.(type) {
 		case reportparser.UsagePage:
-			if info.UsagePage == 0 {
-				info.UsagePage = e.Value()
+			if usagePage == 0 {
+				usagePage = e.Value()
 			}
 		case reportparser.Usage:
-			if info.Usage == 0 {
-				info.Usage = e.Value()
+			if usage == 0 {
+				usage = uint16(e.Value())
 			}
 		}
 	}
+	return []reportparser.ExtendedUsage{reportparser.NewExtendedUsage(usagePage, usage)}
 }
 
 func OpenPath(path string) (*Device, error) {
 	dev, err := os.OpenFile(path, os.O_RDWR, 0755)
 	if err != nil {
-		return nil, err
+		return nil, linuxError(err)
 	}
-	if err := unix.SetNonblock(int(dev.Fd()), true); err != nil {
-		_ = dev.Close()
+
+	return newDevice(dev)
+}
+
+func newDevice(file *os.File) (*Device, error) {
+	d := &Device{
+		file: file,
+	}
+	if err := d.watchRemoval(); err != nil {
+		_ = file.Close()
 		return nil, err
 	}
 
-	return &Device{
-		file: dev,
-	}, nil
+	return d, nil
 }
 
 func (d *Device) Read(ctx context.Context, b []byte) (int, error) {
 	d.readMu.Lock()
 	defer d.readMu.Unlock()
 
-	fd := int(d.file.Fd())
-	for {
-		if err := waitLinuxReadable(ctx, fd); err != nil {
-			return 0, err
-		}
-
-		n, err := unix.Read(fd, b)
-		if errors.Is(err, unix.EAGAIN) {
-			continue
-		}
-		return n, err
+	n, err := readFile(ctx, d.file, b)
+	// hidraw fails reads with EIO once the device is gone.
+	if errors.Is(err, unix.EIO) {
+		err = wrapError(ErrDisconnected, err)
 	}
+
+	return n, d.ioError(err)
 }
 
 func (d *Device) Write(ctx context.Context, b []byte) (int, error) {
+	if len(b) > hidrawMaxBufferSize {
+		return 0, fmt.Errorf("%w: %d bytes exceed %d", ErrReportTooLarge, len(b), hidrawMaxBufferSize)
+	}
+
 	buf := append([]byte(nil), b...)
 	result := runIO(ctx, &d.writeMu, func() ioResult {
 		n, err := d.file.Write(buf)
 
-		return ioResult{n: n, err: err}
+		return ioResult{n: n, err: d.ioError(err)}
 	})
 
 	return result.n, result.err
 }
 
 func (d *Device) SendFeatureReport(report []byte) error {
-	_, _, errno := unix.Syscall(
-		unix.SYS_IOCTL,
-		d.file.Fd(),
-		hidIOCFeature(0x06, len(report)),
-		uintptr(unsafe.Pointer(unsafe.SliceData(report))),
-	)
+	return d.setReport(0x06, report)
+}
+
+func (d *Device) GetFeatureReport(report []byte) (int, error) {
+	return d.getReport(0x07, report)
+}
+
+// SendOutputReport sends an output report over the control pipe with the
+// HIDIOCSOUTPUT ioctl instead of the interrupt pipe that Write may use.
+func (d *Device) SendOutputReport(report []byte) error {
+	return d.setReport(0x0b, report)
+}
+
+// GetInputReport requests an input report over the control pipe with the
+// HIDIOCGINPUT ioctl. The first byte of report selects the report ID.
+func (d *Device) GetInputReport(report []byte) (int, error) {
+	return d.getReport(0x0a, report)
+}
+
+func (d *Device) setReport(command int, report []byte) error {
+	_, err := d.reportIoctl(command, report)
+
+	return err
+}
+
+func (d *Device) getReport(command int, report []byte) (int, error) {
+	return d.reportIoctl(command, report)
+}
 
-	if errno != 0 {
-		return errno
+func (d *Device) reportIoctl(command int, report []byte) (int, error) {
+	if len(report) > hidIOCMaxLength {
+		return 0, fmt.Errorf("%w: %d bytes exceed %d", ErrReportTooLarge, len(report), hidIOCMaxLength)
 	}
 
-	return nil
+	return controlDevice(d, func(fd int) (int, error) {
+		n, _, errno := unix.Syscall(
+			unix.SYS_IOCTL,
+			uintptr(fd),
+			hidIOCFeature(command, len(report)),
+			uintptr(unsafe.Pointer(unsafe.SliceData(report))),
+		)
+
+		if errno != 0 {
+			return 0, errno
+		}
+
+		return int(n), nil
+	})
 }
 
-func (d *Device) GetFeatureReport(report []byte) (int, error) {
-	n, _, errno := unix.Syscall(
-		unix.SYS_IOCTL,
-		d.file.Fd(),
-		hidIOCFeature(0x07, len(report)),
-		uintptr(unsafe.Pointer(unsafe.SliceData(report))),
-	)
+// HIDRawInfo is the bus and device identity that hidraw reports for an open
+// device.
+type HIDRawInfo struct {
+	BusType   BusType
+	VendorID  uint16
+	ProductID uint16
+}
+
+// RawInfo returns the bus type, vendor ID and product ID of the device with
+// the HIDIOCGRAWINFO ioctl.
+func (d *Device) RawInfo() (HIDRawInfo, error) {
+	return controlDevice(d, hidrawInfo)
+}
 
-	if errno != 0 {
-		return 0, errno
+func hidrawInfo(fd int) (HIDRawInfo, error) {
+	rawInfo, err := unix.IoctlHIDGetRawInfo(fd)
+	if err != nil {
+		return HIDRawInfo{}, err
 	}
 
-	return int(n), nil
+	return HIDRawInfo{
+		BusType:   linuxBusType(uint16(rawInfo.Bustype)),
+		VendorID:  uint16(rawInfo.Vendor),
+		ProductID: uint16(rawInfo.Product),
+	}, nil
+}
+
+// RawName returns the device name with the HIDIOCGRAWNAME ioctl. It is the
+// HID_NAME of the sysfs uevent.
+func (d *Device) RawName() (string, error) {
+	return controlDevice(d, unix.IoctlHIDGetRawName)
+}
+
+// RawPhys returns the physical location of the device, such as
+// usb-0000:00:14.0-2/input1, with the HIDIOCGRAWPHYS ioctl.
+func (d *Device) RawPhys() (string, error) {
+	return controlDevice(d, unix.IoctlHIDGetRawPhys)
+}
+
+// RawUniq returns the unique ID of the device with the HIDIOCGRAWUNIQ ioctl.
+// It is the serial number of USB devices and the address of Bluetooth
+// devices, if any.
+func (d *Device) RawUniq() (string, error) {
+	return controlDevice(d, unix.IoctlHIDGetRawUniq)
 }
 
+// ReportDescriptor returns the report descriptor of the device with the
+// HIDIOCGRDESCSIZE and HIDIOCGRDESC ioctls.
+func (d *Device) ReportDescriptor() ([]byte, error) {
+	return controlDevice(d, hidrawReportDescriptor)
+}
+
+// hidIOCFeature returns the read-write hidraw ioctl request of command,
+// which the feature, input and output report ioctls share.
 func hidIOCFeature(command, length int) uintptr {
 	const (
 		iocWrite uintptr = 1
@@ -222,6 +598,76 @@ func hidIOCFeature(command, length int) uintptr {
 		uintptr(command)
 }
 
+// Close closes the device. It wakes a Read or Write that waits for the
+// device, which then returns ErrClosed, and releases the descriptor once no
+// operation uses it.
 func (d *Device) Close() error {
+	d.closed.Store(true)
+	d.done.finish(ErrClosed)
+	if d.removal != nil {
+		_ = d.removal.Close()
+	}
+
 	return d.file.Close()
 }
+
+// ioError wraps err with its sentinel error and ends the device when err
+// reports that it is gone. It returns ErrClosed in place of err after Close.
+func (d *Device) ioError(err error) error {
+	if err == nil {
+		return nil
+	}
+	if d.closed.Load() {
+		return ErrClosed
+	}
+
+	err = linuxError(err)
+	if errors.Is(err, ErrDisconnected) {
+		d.done.finish(ErrDisconnected)
+	}
+
+	return err
+}
+
+// watchRemoval ends the device when hidraw hangs up, which it does once the
+// device is gone. It waits on a duplicate of the descriptor in the Go
+// netpoller, so that it neither consumes reports nor wakes while the device
+// is idle.
+func (d *Device) watchRemoval() error {
+	removalFD, err := controlDevice(d, func(fd int) (int, error) {
+		return unix.FcntlInt(uintptr(fd), unix.F_DUPFD_CLOEXEC, 0)
+	})
+	if err != nil {
+		return err
+	}
+	d.removal = os.NewFile(uintptr(removalFD), d.file.Name())
+
+	conn, err := d.removal.SyscallConn()
+	if err != nil {
+		_ = d.removal.Close()
+		return err
+	}
+	go func() {
+		// Read calls the function again whenever the descriptor signals
+		// readiness and returns nil once it reports a hang-up. It fails
+		// once Close closes the duplicate.
+		err := conn.Read(func(fd uintptr) bool {
+			return hungUp(int(fd))
+		})
+		if err == nil {
+			d.done.finish(ErrDisconnected)
+		}
+	}()
+
+	return nil
+}
+
+// hungUp reports whether fd is in the POLLHUP or POLLERR state, without
+// waiting for it.
+func hungUp(fd int) bool {
+	pollFDs := []unix.PollFd{{Fd: int32(fd)}}
+
+	ready, err := unix.Poll(pollFDs, 0)
+
+	return err == nil && ready > 0 && pollFDs[0].Revents&(unix.POLLHUP|unix.POLLERR) != 0
+}
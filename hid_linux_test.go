@@ -0,0 +1,333 @@
+//go:build linux
+
+package hid
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+
+	"golang.org/x/sys/unix"
+)
+
+// compositeDescriptor declares a keyboard and a consumer control collection.
+var compositeDescriptor = []byte{
+	0x05, 0x01, // Usage Page (Generic Desktop)
+	0x09, 0x06, // Usage (Keyboard)
+	0xa1, 0x01, // Collection (Application)
+	0x85, 0x01, //   Report ID (1)
+	0x05, 0x07, //   Usage Page (Keyboard/Keypad)
+	0x19, 0xe0, //   Usage Minimum (Keyboard LeftControl)
+	0x29, 0xe7, //   Usage Maximum (Keyboard Right GUI)
+	0x15, 0x00, //   Logical Minimum (0)
+	0x25, 0x01, //   Logical Maximum (1)
+	0x75, 0x01, //   Report Size (1)
+	0x95, 0x08, //   Report Count (8)
+	0x81, 0x02, //   Input (Variable)
+	0xc0,       // End Collection
+	0x05, 0x0c, // Usage Page (Consumer)
+	0x09, 0x01, // Usage (Consumer Control)
+	0xa1, 0x01, // Collection (Application)
+	0x85, 0x02, //   Report ID (2)
+	0x09, 0xe9, //   Usage (Volume Increment)
+	0x09, 0xea, //   Usage (Volume Decrement)
+	0x15, 0x00, //   Logical Minimum (0)
+	0x25, 0x01, //   Logical Maximum (1)
+	0x75, 0x01, //   Report Size (1)
+	0x95, 0x02, //   Report Count (2)
+	0x81, 0x02, //   Input (Variable)
+	0x95, 0x06, //   Report Count (6)
+	0x81, 0x03, //   Input (Constant, Variable)
+	0xc0, // End Collection
+}
+
+func TestDeviceInfoPerCollection(t *testing.T) {
+	info := &DeviceInfo{Path: "/dev/hidraw3", VendorID: 0x1234}
+	infos := deviceInfoPerCollection(info, compositeDescriptor)
+
+	want := []*DeviceInfo{
+		{Path: "/dev/hidraw3", VendorID: 0x1234, UsagePage: 0x01, Usage: 0x06},
+		{Path: "/dev/hidraw3", VendorID: 0x1234, UsagePage: 0x0c, Usage: 0x01},
+	}
+	if !reflect.DeepEqual(infos, want) {
+		t.Fatalf("deviceInfoPerCollection() = %+v, want %+v", infos, want)
+	}
+
+	var matched []*DeviceInfo
+	opts := newEnumerateOptions([]EnumerateOption{WithUsagePage(0x0c), WithUsage(0x01)})
+	for _, info := range infos {
+		if opts.match(info) {
+			matched = append(matched, info)
+		}
+	}
+	if len(matched) != 1 || matched[0] != infos[1] {
+		t.Fatalf("consumer control filter matched %+v", matched)
+	}
+}
+
+func TestFillDeviceInfoUsageFallback(t *testing.T) {
+	// An unclosed collection does not resolve, so the first usage is used.
+	info := &DeviceInfo{}
+	fillDeviceInfoUsage(info, []byte{0x06, 0xd0, 0xf1, 0x09, 0x01, 0xa1, 0x01})
+	if info.UsagePage != 0xf1d0 || info.Usage != 0x01 {
+		t.Fatalf("usage = %#x:%#x, want 0xf1d0:0x1", info.UsagePage, info.Usage)
+	}
+
+	info = &DeviceInfo{}
+	fillDeviceInfoUsage(info, nil)
+	if info.UsagePage != 0 || info.Usage != 0 {
+		t.Fatalf("usage of an empty descriptor = %#x:%#x, want zero", info.UsagePage, info.Usage)
+	}
+}
+
+func TestFillDeviceInfoFromUevent(t *testing.T) {
+	tests := []struct {
+		uevent string
+		want   DeviceInfo
+	}{
+		{
+			uevent: "HID_ID=0003:00001050:00000407\nHID_NAME=Yubico YubiKey\nHID_UNIQ=\n",
+			want:   DeviceInfo{BusType: BusUSB, VendorID: 0x1050, ProductID: 0x0407, ProductStr: "Yubico YubiKey"},
+		},
+		{
+			uevent: "HID_ID=0005:0000046D:0000B023\nHID_UNIQ=d4:8a:11:22:33:44\n",
+			want:   DeviceInfo{BusType: BusBluetooth, VendorID: 0x046d, ProductID: 0xb023, SerialNbr: "d4:8a:11:22:33:44"},
+		},
+		{
+			uevent: "HID_ID=0018:000004F3:00003182\n",
+			want:   DeviceInfo{BusType: BusI2C, VendorID: 0x04f3, ProductID: 0x3182},
+		},
+		{
+			// BUS_HOST has no constant.
+			uevent: "HID_ID=0019:00000000:00000000\n",
+			want:   DeviceInfo{},
+		},
+	}
+
+	for _, test := range tests {
+		var info DeviceInfo
+		if err := fillDeviceInfoFromUevent(&info, []byte(test.uevent)); err != nil {
+			t.Fatalf("fillDeviceInfoFromUevent(%q): %v", test.uevent, err)
+		}
+		if info != test.want {
+			t.Fatalf("fillDeviceInfoFromUevent(%q) = %+v, want %+v", test.uevent, info, test.want)
+		}
+	}
+}
+
+// The sysfs tree in testdata replaces the colons of sysfs device names with
+// underscores, which module zips and Windows checkouts do not allow. Module
+// zips leave out symlinks too, so linuxSysfsFixture adds them to a copy.
+func TestFillDeviceInfoFromSysfsParents(t *testing.T) {
+	tests := []struct {
+		name string
+		info DeviceInfo
+		want DeviceInfo
+	}{
+		{
+			name: "hidraw0",
+			want: DeviceInfo{
+				SerialNbr:      "0012345678",
+				ReleaseNbr:     0x0512,
+				MfrStr:         "Yubico",
+				InterfaceNbr:   1,
+				InstanceID:     "/devices/pci0000_00/0000_00_14.0/usb1/1-2/1-2_1.1/0003_1050_0407.0001",
+				ParentDeviceID: "/devices/pci0000_00/0000_00_14.0/usb1/1-2/1-2_1.1",
+			},
+		},
+		{
+			// A Bluetooth device behind a USB adapter must not take the
+			// adapter's USB metadata.
+			name: "hidraw1",
+			info: DeviceInfo{SerialNbr: "d4:8a:11:22:33:44"},
+			want: DeviceInfo{
+				SerialNbr:      "d4:8a:11:22:33:44",
+				InstanceID:     "/devices/pci0000_00/0000_00_14.0/usb1/1-7/1-7_1.0/bluetooth/hci0/hci0_256/0005_046D_B023.0002",
+				ParentDeviceID: "/devices/pci0000_00/0000_00_14.0/usb1/1-7/1-7_1.0/bluetooth/hci0/hci0_256",
+			},
+		},
+		{
+			// A uhid device declaring the USB bus is virtual.
+			name: "hidraw2",
+			info: DeviceInfo{BusType: BusUSB},
+			want: DeviceInfo{
+				BusType:        BusVirtual,
+				InstanceID:     "/devices/virtual/misc/uhid/0003_1050_0407.0003",
+				ParentDeviceID: "/devices/virtual/misc/uhid",
+			},
+		},
+	}
+
+	sysfsDir := linuxSysfsFixture(t)
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			info := test.info
+			devicePath := filepath.Join(sysfsDir, "class", "hidraw", test.name, "device")
+			if err := fillDeviceInfoFromSysfsParents(&info, sysfsDir, devicePath); err != nil {
+				t.Fatal(err)
+			}
+			if info != test.want {
+				t.Fatalf("device info = %+v, want %+v", info, test.want)
+			}
+		})
+	}
+}
+
+func TestLinuxBackendEnumerate(t *testing.T) {
+	backend := &LinuxBackend{
+		SysfsDir:  linuxSysfsFixture(t),
+		DeviceDir: "/run/hid/dev",
+	}
+
+	var infos []*DeviceInfo
+	for info, err := range backend.Enumerate() {
+		if err != nil {
+			t.Fatal(err)
+		}
+		infos = append(infos, info)
+	}
+	slices.SortFunc(infos, func(a, b *DeviceInfo) int {
+		return strings.Compare(a.Path, b.Path)
+	})
+
+	want := []*DeviceInfo{
+		{
+			Path:           "/run/hid/dev/hidraw0",
+			VendorID:       0x1050,
+			ProductID:      0x0407,
+			SerialNbr:      "0012345678",
+			ReleaseNbr:     0x0512,
+			MfrStr:         "Yubico",
+			ProductStr:     "Yubico YubiKey OTP+FIDO+CCID",
+			UsagePage:      0xf1d0,
+			Usage:          0x01,
+			InterfaceNbr:   1,
+			InstanceID:     "/devices/pci0000_00/0000_00_14.0/usb1/1-2/1-2_1.1/0003_1050_0407.0001",
+			ParentDeviceID: "/devices/pci0000_00/0000_00_14.0/usb1/1-2/1-2_1.1",
+			BusType:        BusUSB,
+		},
+		{
+			Path:           "/run/hid/dev/hidraw1",
+			VendorID:       0x046d,
+			ProductID:      0xb023,
+			SerialNbr:      "d4:8a:11:22:33:44",
+			ProductStr:     "MX Master 3",
+			UsagePage:      0x01,
+			Usage:          0x02,
+			InstanceID:     "/devices/pci0000_00/0000_00_14.0/usb1/1-7/1-7_1.0/bluetooth/hci0/hci0_256/0005_046D_B023.0002",
+			ParentDeviceID: "/devices/pci0000_00/0000_00_14.0/usb1/1-7/1-7_1.0/bluetooth/hci0/hci0_256",
+			BusType:        BusBluetooth,
+		},
+		{
+			Path:           "/run/hid/dev/hidraw2",
+			VendorID:       0x1050,
+			ProductID:      0x0407,
+			ProductStr:     "Yubico YubiKey OTP+FIDO+CCID",
+			UsagePage:      0xf1d0,
+			Usage:          0x01,
+			InstanceID:     "/devices/virtual/misc/uhid/0003_1050_0407.0003",
+			ParentDeviceID: "/devices/virtual/misc/uhid",
+			BusType:        BusVirtual,
+		},
+	}
+	if !reflect.DeepEqual(infos, want) {
+		t.Fatalf("Enumerate() = %+v, want %+v", infos, want)
+	}
+
+	var paths []string
+	for info, err := range backend.Enumerate(WithUsagePage(0xf1d0), WithBusType(BusUSB)) {
+		if err != nil {
+			t.Fatal(err)
+		}
+		paths = append(paths, info.Path)
+	}
+	if !slices.Equal(paths, []string{"/run/hid/dev/hidraw0"}) {
+		t.Fatalf("USB FIDO devices = %q, want only hidraw0", paths)
+	}
+}
+
+// linuxSysfsFixture copies the sysfs tree of testdata to a temporary
+// directory and links its hidraw class entries and device directories as
+// sysfs does.
+func linuxSysfsFixture(t *testing.T) string {
+	t.Helper()
+
+	dir := filepath.Join(t.TempDir(), "sysfs")
+	if err := os.CopyFS(dir, os.DirFS(filepath.Join("testdata", "sysfs"))); err != nil {
+		t.Fatal(err)
+	}
+	for _, device := range []string{
+		"pci0000_00/0000_00_14.0/usb1/1-2/1-2_1.1/0003_1050_0407.0001/hidraw/hidraw0",
+		"pci0000_00/0000_00_14.0/usb1/1-7/1-7_1.0/bluetooth/hci0/hci0_256/0005_046D_B023.0002/hidraw/hidraw1",
+		"virtual/misc/uhid/0003_1050_0407.0003/hidraw/hidraw2",
+	} {
+		classLink := filepath.Join(dir, "class", "hidraw", filepath.Base(device))
+		if err := os.MkdirAll(filepath.Dir(classLink), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.Symlink(filepath.Join("..", "..", "devices", device), classLink); err != nil {
+			t.Fatal(err)
+		}
+		// The device link of a hidraw node points at its HID device, two
+		// levels up.
+		hidDevice := filepath.Base(filepath.Dir(filepath.Dir(device)))
+		if err := os.Symlink(filepath.Join("..", "..", "..", hidDevice), filepath.Join(dir, "devices", device, "device")); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	return dir
+}
+
+func TestLinuxBackendEnumerateMissingDirectories(t *testing.T) {
+	dir := t.TempDir()
+	backend := &LinuxBackend{
+		SysfsDir:  filepath.Join(dir, "sys"),
+		DeviceDir: filepath.Join(dir, "dev"),
+	}
+	var errs []error
+	for _, err := range backend.Enumerate() {
+		errs = append(errs, err)
+	}
+	if len(errs) != 1 || !errors.Is(errs[0], os.ErrNotExist) {
+		t.Fatalf("Enumerate() errors = %v, want one ErrNotExist", errs)
+	}
+}
+
+func TestLinuxBackendHIDRawFallback(t *testing.T) {
+	dir := t.TempDir()
+	backend := &LinuxBackend{
+		SysfsDir:  filepath.Join(dir, "sys"),
+		DeviceDir: filepath.Join(dir, "dev"),
+	}
+	if err := os.Mkdir(backend.DeviceDir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{"hidraw0", "hidraw12", "hidraw", "hidrawx", "tty0"} {
+		if err := os.WriteFile(filepath.Join(backend.DeviceDir, name), nil, 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	names, err := backend.hidrawNames(backend.hasSysfs())
+	if err != nil {
+		t.Fatal(err)
+	}
+	slices.Sort(names)
+	if !slices.Equal(names, []string{"hidraw0", "hidraw12"}) {
+		t.Fatalf("hidrawNames() = %q, want hidraw0 and hidraw12", names)
+	}
+
+	// Regular files do not support the hidraw ioctls.
+	info, err := backend.deviceInfo("hidraw12", false)
+	if !errors.Is(err, unix.ENOTTY) {
+		t.Fatalf("deviceInfo() error = %v, want ENOTTY", err)
+	}
+	if info.Path != filepath.Join(backend.DeviceDir, "hidraw12") {
+		t.Fatalf("partial device info path = %q", info.Path)
+	}
+}
@@ -23,6 +23,8 @@ var (
 	procHidD_GetSerialNumberString       = modHidsdi.NewProc("HidD_GetSerialNumberString")
 	procHidD_GetFeature                  = modHidsdi.NewProc("HidD_GetFeature")
 	procHidD_SetFeature                  = modHidsdi.NewProc("HidD_SetFeature")
+	procHidD_GetInputReport              = modHidsdi.NewProc("HidD_GetInputReport")
+	procHidD_SetOutputReport             = modHidsdi.NewProc("HidD_SetOutputReport")
 	procHidD_GetPreparsedData            = modHidsdi.NewProc("HidD_GetPreparsedData")
 	procHidD_FreePreparsedData           = modHidsdi.NewProc("HidD_FreePreparsedData")
 	procHidP_GetCaps                     = modHidsdi.NewProc("HidP_GetCaps")
@@ -322,6 +324,21 @@ func setupDiGetDevicePropertyW(
 	return devPropType, propertyBuffer, nil
 }
 
+// windowsBusType derives the bus from the enumerator prefix of the parent
+// device instance ID. I2C and SPI devices are enumerated by ACPI and are not
+// distinguished.
+func windowsBusType(parentDeviceID string) BusType {
+	enumerator, _, _ := strings.Cut(parentDeviceID, `\`)
+	switch strings.ToUpper(enumerator) {
+	case "USB":
+		return BusUSB
+	case "BTHENUM", "BTHLEDEVICE":
+		return BusBluetooth
+	default:
+		return ""
+	}
+}
+
 func getDeviceInfo(devPath string) (*DeviceInfo, error) {
 	devicePathPtr := windows.StringToUTF16Ptr(devPath)
 
@@ -515,6 +532,7 @@ func Enumerate(options ...EnumerateOption) iter.Seq2[*DeviceInfo, error] {
 			}
 			u16ParentBuf := unsafe.Slice((*uint16)(unsafe.Pointer(&parentBuf[0])), len(parentBuf)/2)
 			deviceInfo.ParentDeviceID = windows.UTF16ToString(u16ParentBuf)
+			deviceInfo.BusType = windowsBusType(deviceInfo.ParentDeviceID)
 
 			if !opts.match(deviceInfo) {
 				continue
@@ -555,7 +573,7 @@ func OpenPath(path string, opts ...Option) (*Device, error) {
 		0,
 	)
 	if err != nil {
-		return nil, err
+		return nil, windowsError(err)
 	}
 	closeOnError := true
 	defer func() {
@@ -566,7 +584,7 @@ func OpenPath(path string, opts ...Option) (*Device, error) {
 
 	preparsedData, err := getPreparsedData(hFile)
 	if err != nil {
-		return nil, err
+		return nil, windowsError(err)
 	}
 	defer func() {
 		_ = freePreparsedData(preparsedData)
@@ -580,6 +598,9 @@ func OpenPath(path string, opts ...Option) (*Device, error) {
 	d.outputReportByteLength = caps.OutputReportByteLength
 	d.featureReportByteLength = caps.FeatureReportByteLength
 	d.hFile = hFile
+	if err := d.watchRemoval(); err != nil {
+		return nil, err
+	}
 	closeOnError = false
 
 	return d, nil
@@ -593,9 +614,15 @@ func (d *Device) Read(ctx context.Context, p []byte) (n int, err error) {
 
 		return 0, err
 	}
+	if err := d.beginOperation(); err != nil {
+		d.readMu.Unlock()
+
+		return 0, err
+	}
 
 	hEvent, err := windows.CreateEvent(nil, 0, 0, nil)
 	if err != nil {
+		d.operations.Done()
 		d.readMu.Unlock()
 		return 0, err
 	}
@@ -607,6 +634,7 @@ func (d *Device) Read(ctx context.Context, p []byte) (n int, err error) {
 	result := make(chan ioResult, 1)
 	go func() {
 		defer d.readMu.Unlock()
+		defer d.operations.Done()
 		defer func() { _ = windows.Close(hEvent) }()
 		result <- d.read(overlapped)
 	}()
@@ -619,7 +647,7 @@ func (d *Device) Read(ctx context.Context, p []byte) (n int, err error) {
 
 	case r := <-result:
 		if r.err != nil {
-			return 0, r.err
+			return 0, d.ioError(r.err)
 		}
 
 		return copy(p, r.data), nil
@@ -634,6 +662,7 @@ func (d *Device) read(overlapped *windows.Overlapped) ioResult {
 			return ioResult{err: err}
 		}
 	}
+	d.cancelIfClosed(overlapped)
 
 	event, err := windows.WaitForSingleObject(overlapped.HEvent, d.readTimeout)
 	if err != nil {
@@ -662,6 +691,10 @@ func (d *Device) read(overlapped *windows.Overlapped) ioResult {
 }
 
 func (d *Device) Write(ctx context.Context, p []byte) (n int, err error) {
+	if err := checkReportLength(p, d.outputReportByteLength); err != nil {
+		return 0, err
+	}
+
 	buf := make([]byte, d.outputReportByteLength)
 	copy(buf, p)
 
@@ -672,9 +705,15 @@ func (d *Device) Write(ctx context.Context, p []byte) (n int, err error) {
 
 		return 0, err
 	}
+	if err := d.beginOperation(); err != nil {
+		d.writeMu.Unlock()
+
+		return 0, err
+	}
 
 	hEvent, err := windows.CreateEvent(nil, 0, 0, nil)
 	if err != nil {
+		d.operations.Done()
 		d.writeMu.Unlock()
 		return 0, err
 	}
@@ -683,6 +722,7 @@ func (d *Device) Write(ctx context.Context, p []byte) (n int, err error) {
 	result := make(chan ioResult, 1)
 	go func() {
 		defer d.writeMu.Unlock()
+		defer d.operations.Done()
 		defer func() { _ = windows.Close(hEvent) }()
 		result <- d.write(buf, overlapped)
 	}()
@@ -694,7 +734,7 @@ func (d *Device) Write(ctx context.Context, p []byte) (n int, err error) {
 		return 0, ctx.Err()
 
 	case r := <-result:
-		return r.n, r.err
+		return r.n, d.ioError(r.err)
 	}
 }
 
@@ -705,6 +745,7 @@ func (d *Device) write(buf []byte, overlapped *windows.Overlapped) ioResult {
 			return ioResult{err: err}
 		}
 	}
+	d.cancelIfClosed(overlapped)
 
 	if err := windowsGetOverlappedResult(d.hFile, overlapped, &done, true); err != nil {
 		return ioResult{err: err}
@@ -718,47 +759,285 @@ func (d *Device) write(buf []byte, overlapped *windows.Overlapped) ioResult {
 }
 
 func (d *Device) SendFeatureReport(report []byte) error {
+	if err := checkReportLength(report, d.featureReportByteLength); err != nil {
+		return err
+	}
+
 	buffer := make([]byte, d.featureReportByteLength)
 	copy(buffer, report)
 
+	if err := d.beginOperation(); err != nil {
+		return err
+	}
+	defer d.operations.Done()
+
 	r1, _, err := procHidD_SetFeature.Call(
 		uintptr(d.hFile),
 		uintptr(unsafe.Pointer(unsafe.SliceData(buffer))),
 		uintptr(len(buffer)),
 	)
 	if r1 == 0 {
-		return err
+		return d.ioError(err)
 	}
 
 	return nil
 }
 
 func (d *Device) GetFeatureReport(report []byte) (int, error) {
+	if err := checkReportRequest(report, d.featureReportByteLength); err != nil {
+		return 0, err
+	}
+
 	buffer := make([]byte, d.featureReportByteLength)
 	buffer[0] = report[0]
 
+	if err := d.beginOperation(); err != nil {
+		return 0, err
+	}
+	defer d.operations.Done()
+
 	r1, _, err := procHidD_GetFeature.Call(
 		uintptr(d.hFile),
 		uintptr(unsafe.Pointer(unsafe.SliceData(buffer))),
 		uintptr(len(buffer)),
 	)
 	if r1 == 0 {
+		return 0, d.ioError(err)
+	}
+
+	return copy(report, buffer), nil
+}
+
+// SendOutputReport sends an output report with HidD_SetOutputReport, which
+// uses the control pipe of USB devices instead of the interrupt pipe that
+// Write uses.
+func (d *Device) SendOutputReport(report []byte) error {
+	if err := checkReportLength(report, d.outputReportByteLength); err != nil {
+		return err
+	}
+
+	buffer := make([]byte, d.outputReportByteLength)
+	copy(buffer, report)
+
+	if err := d.beginOperation(); err != nil {
+		return err
+	}
+	defer d.operations.Done()
+
+	r1, _, err := procHidD_SetOutputReport.Call(
+		uintptr(d.hFile),
+		uintptr(unsafe.Pointer(unsafe.SliceData(buffer))),
+		uintptr(len(buffer)),
+	)
+	if r1 == 0 {
+		return d.ioError(err)
+	}
+
+	return nil
+}
+
+// GetInputReport requests an input report with HidD_GetInputReport. The
+// first byte of report selects the report ID.
+func (d *Device) GetInputReport(report []byte) (int, error) {
+	if err := checkReportRequest(report, d.inputReportByteLength); err != nil {
+		return 0, err
+	}
+
+	buffer := make([]byte, d.inputReportByteLength)
+	buffer[0] = report[0]
+
+	if err := d.beginOperation(); err != nil {
 		return 0, err
 	}
+	defer d.operations.Done()
+
+	r1, _, err := procHidD_GetInputReport.Call(
+		uintptr(d.hFile),
+		uintptr(unsafe.Pointer(unsafe.SliceData(buffer))),
+		uintptr(len(buffer)),
+	)
+	if r1 == 0 {
+		return 0, d.ioError(err)
+	}
 
 	return copy(report, buffer), nil
 }
 
+// Close closes the device. It cancels the pending I/O of the device, which
+// makes Read and Write return ErrClosed, and closes the handle once no
+// operation uses it. If a HidD call ignores the cancellation, Close returns
+// after a few seconds and the handle is closed when the call returns.
 func (d *Device) Close() error {
 	d.closeOnce.Do(func() {
+		d.closeMu.Lock()
+		d.closed = true
+		d.closeMu.Unlock()
+
+		d.done.finish(ErrClosed)
+
 		if err := windowsCancelIoEx(d.hFile, nil); err != nil && !errors.Is(err, windows.ERROR_NOT_FOUND) {
 			d.closeErr = err
 		}
 
-		if err := windows.Close(d.hFile); err != nil && d.closeErr == nil {
-			d.closeErr = err
+		var releaseErr error
+		if releaseWhenIdle(d.waitOperations, func() {
+			releaseErr = d.release()
+		}) && d.closeErr == nil {
+			d.closeErr = releaseErr
 		}
 	})
 
 	return d.closeErr
 }
+
+// waitOperations waits until no operation uses the handle. It cancels the
+// I/O of the handle again while it waits, because an operation that passed
+// beginOperation before Close may issue its request after the first
+// cancellation.
+func (d *Device) waitOperations() {
+	idle := make(chan struct{})
+	go func() {
+		d.operations.Wait()
+		close(idle)
+	}()
+
+	ticker := time.NewTicker(10 * time.Millisecond)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-idle:
+			return
+		case <-ticker.C:
+			_ = windowsCancelIoEx(d.hFile, nil)
+		}
+	}
+}
+
+// release unregisters the removal notification and closes the handle.
+func (d *Device) release() error {
+	var err error
+	if d.notify != 0 {
+		err = cmUnregisterNotification(d.notify)
+		cmDevices.Delete(d.cbID)
+	}
+	if closeErr := windows.Close(d.hFile); closeErr != nil && err == nil {
+		err = closeErr
+	}
+
+	return err
+}
+
+// watchRemoval registers for the notifications of the handle, which end the
+// device once it is removed.
+func (d *Device) watchRemoval() error {
+	cbID := uintptr(cmDeviceSeq.Add(1))
+	cmDevices.Store(cbID, d)
+
+	filter := &_CM_NOTIFY_FILTER{
+		CbSize:     uint32(unsafe.Sizeof(_CM_NOTIFY_FILTER{})),
+		FilterType: _CM_NOTIFY_FILTER_TYPE_DEVICEHANDLE,
+	}
+	*(*windows.Handle)(unsafe.Pointer(&filter.U[0])) = d.hFile
+
+	notify, err := cmRegisterNotification(filter, cbID, cmDeviceCallback)
+	if err != nil {
+		cmDevices.Delete(cbID)
+		return err
+	}
+	d.notify = notify
+	d.cbID = cbID
+
+	return nil
+}
+
+// beginOperation registers an operation that uses the handle, which Close
+// waits for. The operation calls operations.Done when it no longer uses the
+// handle.
+func (d *Device) beginOperation() error {
+	d.closeMu.RLock()
+	defer d.closeMu.RUnlock()
+
+	if d.closed {
+		return ErrClosed
+	}
+	d.operations.Add(1)
+
+	return nil
+}
+
+// cancelIfClosed cancels the overlapped I/O when Close ran before it was
+// issued, because the CancelIoEx call of Close does not cover it then.
+func (d *Device) cancelIfClosed(overlapped *windows.Overlapped) {
+	d.closeMu.RLock()
+	closed := d.closed
+	d.closeMu.RUnlock()
+
+	if closed {
+		_ = windowsCancelIoEx(d.hFile, overlapped)
+	}
+}
+
+// ioError wraps err with its sentinel error and ends the device when err
+// reports that it is gone. It returns ErrClosed in place of err after Close.
+func (d *Device) ioError(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	d.closeMu.RLock()
+	closed := d.closed
+	d.closeMu.RUnlock()
+	if closed {
+		return ErrClosed
+	}
+
+	err = windowsError(err)
+	if errors.Is(err, ErrDisconnected) {
+		d.done.finish(ErrDisconnected)
+	}
+
+	return err
+}
+
+// windowsError wraps err with the sentinel error that its error code stands
+// for.
+func windowsError(err error) error {
+	switch {
+	case errors.Is(err, windows.ERROR_FILE_NOT_FOUND), errors.Is(err, windows.ERROR_PATH_NOT_FOUND):
+		return wrapError(ErrDeviceNotFound, err)
+	case errors.Is(err, windows.ERROR_ACCESS_DENIED):
+		return wrapError(ErrPermission, err)
+	case errors.Is(err, windows.ERROR_DEVICE_NOT_CONNECTED),
+		errors.Is(err, windows.ERROR_DEV_NOT_EXIST),
+		errors.Is(err, windows.ERROR_NO_SUCH_DEVICE),
+		errors.Is(err, windows.ERROR_DEVICE_REMOVED):
+		return wrapError(ErrDisconnected, err)
+	}
+
+	return err
+}
+
+// checkReportRequest checks the buffer of a Get method, which begins with the
+// report ID of the requested report. A longer buffer than the report is fine.
+// The device must have reports of the type, whose report byte length is 0
+// otherwise.
+func checkReportRequest(report []byte, reportByteLength uint16) error {
+	if len(report) == 0 {
+		return errors.New("report buffer is empty; it must begin with the report ID")
+	}
+	if reportByteLength == 0 {
+		return fmt.Errorf("%w: the device has no reports of this type", ErrReportTooLarge)
+	}
+
+	return nil
+}
+
+// checkReportLength returns ErrReportTooLarge when report, which begins with
+// the report ID, exceeds the report byte length of the device.
+func checkReportLength(report []byte, reportByteLength uint16) error {
+	if len(report) > int(reportByteLength) {
+		return fmt.Errorf("%w: %d bytes exceed %d", ErrReportTooLarge, len(report), reportByteLength)
+	}
+
+	return nil
+}
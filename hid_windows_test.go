@@ -3,6 +3,7 @@ package hid
 import (
 	"context"
 	"errors"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -23,6 +24,21 @@ func TestGetHidGuid(t *testing.T) {
 	}
 }
 
+func TestWindowsBusType(t *testing.T) {
+	tests := map[string]BusType{
+		`USB\VID_1050&PID_0407&MI_01\7&1A2B3C4D&0&0001`:                            BusUSB,
+		`BTHENUM\{00001124-0000-1000-8000-00805f9b34fb}_VID&0002046d_PID&b023\8&1`: BusBluetooth,
+		`BTHLEDevice\{00001812-0000-1000-8000-00805f9b34fb}_Dev_VID&02046d\9&2`:    BusBluetooth,
+		`ACPI\PNP0C50\1`: "",
+		"":               "",
+	}
+	for parentDeviceID, want := range tests {
+		if got := windowsBusType(parentDeviceID); got != want {
+			t.Errorf("windowsBusType(%q) = %q, want %q", parentDeviceID, got, want)
+		}
+	}
+}
+
 func TestReadCancellationCallsCancelIoEx(t *testing.T) {
 	originalReadFile := windowsReadFile
 	originalCancelIoEx := windowsCancelIoEx
@@ -73,3 +89,187 @@ func TestReadCancellationCallsCancelIoEx(t *testing.T) {
 	}
 	close(release)
 }
+
+func TestCloseUnblocksRead(t *testing.T) {
+	originalReadFile := windowsReadFile
+	originalCancelIoEx := windowsCancelIoEx
+	originalGetOverlappedResult := windowsGetOverlappedResult
+	t.Cleanup(func() {
+		windowsReadFile = originalReadFile
+		windowsCancelIoEx = originalCancelIoEx
+		windowsGetOverlappedResult = originalGetOverlappedResult
+	})
+
+	var pending atomic.Pointer[windows.Overlapped]
+	started := make(chan struct{})
+	windowsReadFile = func(_ windows.Handle, _ []byte, _ *uint32, overlapped *windows.Overlapped) error {
+		pending.Store(overlapped)
+		close(started)
+		return windows.ERROR_IO_PENDING
+	}
+	windowsCancelIoEx = func(_ windows.Handle, _ *windows.Overlapped) error {
+		if overlapped := pending.Load(); overlapped != nil {
+			return windows.SetEvent(overlapped.HEvent)
+		}
+		return windows.ERROR_NOT_FOUND
+	}
+	windowsGetOverlappedResult = func(_ windows.Handle, _ *windows.Overlapped, _ *uint32, _ bool) error {
+		return windows.ERROR_OPERATION_ABORTED
+	}
+
+	// Any handle that Close can close stands in for the device.
+	hFile, err := windows.CreateEvent(nil, 0, 0, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	device := &Device{hFile: hFile, inputReportByteLength: 64, readTimeout: windows.INFINITE}
+	done := make(chan error, 1)
+	go func() {
+		_, err := device.Read(context.Background(), make([]byte, 64))
+		done <- err
+	}()
+
+	<-started
+	if err := device.Close(); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case err := <-done:
+		if !errors.Is(err, ErrClosed) {
+			t.Fatalf("error = %v, want ErrClosed", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Read did not return after Close")
+	}
+
+	if _, err := device.Read(context.Background(), make([]byte, 64)); !errors.Is(err, ErrClosed) {
+		t.Fatalf("Read() after Close error = %v, want ErrClosed", err)
+	}
+	if _, err := device.Write(context.Background(), make([]byte, 64)); !errors.Is(err, ErrClosed) {
+		t.Fatalf("Write() after Close error = %v, want ErrClosed", err)
+	}
+}
+
+func TestCloseReturnsDuringStuckOperation(t *testing.T) {
+	originalCancelIoEx := windowsCancelIoEx
+	originalTimeout := closeTimeout
+	t.Cleanup(func() {
+		windowsCancelIoEx = originalCancelIoEx
+		closeTimeout = originalTimeout
+	})
+	closeTimeout = 50 * time.Millisecond
+
+	var cancels atomic.Int32
+	windowsCancelIoEx = func(_ windows.Handle, _ *windows.Overlapped) error {
+		cancels.Add(1)
+		return windows.ERROR_NOT_FOUND
+	}
+
+	hFile, err := windows.CreateEvent(nil, 0, 0, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	device := &Device{hFile: hFile, inputReportByteLength: 64, readTimeout: windows.INFINITE}
+	// A HidD call that ignores the cancellation never ends its operation.
+	if err := device.beginOperation(); err != nil {
+		t.Fatal(err)
+	}
+	defer device.operations.Done()
+
+	done := make(chan error, 1)
+	go func() {
+		done <- device.Close()
+	}()
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatalf("Close() error = %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Close did not return during a stuck operation")
+	}
+	if cancels.Load() < 2 {
+		t.Errorf("CancelIoEx called %d times, want the I/O cancelled again while Close waits", cancels.Load())
+	}
+}
+
+func TestWindowsError(t *testing.T) {
+	tests := []struct {
+		err  error
+		want error
+	}{
+		{windows.ERROR_FILE_NOT_FOUND, ErrDeviceNotFound},
+		{windows.ERROR_PATH_NOT_FOUND, ErrDeviceNotFound},
+		{windows.ERROR_ACCESS_DENIED, ErrPermission},
+		{windows.ERROR_DEVICE_NOT_CONNECTED, ErrDisconnected},
+		{windows.ERROR_DEV_NOT_EXIST, ErrDisconnected},
+		{windows.ERROR_NO_SUCH_DEVICE, ErrDisconnected},
+		{windows.ERROR_DEVICE_REMOVED, ErrDisconnected},
+	}
+	for _, test := range tests {
+		err := windowsError(test.err)
+		if !errors.Is(err, test.want) {
+			t.Errorf("windowsError(%v) = %v, want %v", test.err, err, test.want)
+		}
+		if !errors.Is(err, test.err) {
+			t.Errorf("windowsError(%v) = %v, does not match the error code", test.err, err)
+		}
+	}
+
+	if err := windowsError(windows.ERROR_GEN_FAILURE); err != windows.ERROR_GEN_FAILURE {
+		t.Errorf("windowsError(ERROR_GEN_FAILURE) = %v, want ERROR_GEN_FAILURE", err)
+	}
+}
+
+func TestReportTooLarge(t *testing.T) {
+	device := &Device{outputReportByteLength: 3, featureReportByteLength: 3}
+
+	if _, err := device.Write(context.Background(), []byte{0, 1, 2, 3}); !errors.Is(err, ErrReportTooLarge) {
+		t.Errorf("Write() error = %v, want ErrReportTooLarge", err)
+	}
+	if err := device.SendOutputReport([]byte{0, 1, 2, 3}); !errors.Is(err, ErrReportTooLarge) {
+		t.Errorf("SendOutputReport() error = %v, want ErrReportTooLarge", err)
+	}
+	if err := device.SendFeatureReport([]byte{0, 1, 2, 3}); !errors.Is(err, ErrReportTooLarge) {
+		t.Errorf("SendFeatureReport() error = %v, want ErrReportTooLarge", err)
+	}
+}
+
+func TestGetReportChecksBuffer(t *testing.T) {
+	device := &Device{inputReportByteLength: 3}
+
+	if _, err := device.GetInputReport(nil); err == nil {
+		t.Error("GetInputReport(nil) succeeded")
+	}
+	if _, err := device.GetFeatureReport([]byte{}); err == nil {
+		t.Error("GetFeatureReport([]byte{}) succeeded")
+	}
+	if _, err := device.GetFeatureReport([]byte{0, 0, 0}); !errors.Is(err, ErrReportTooLarge) {
+		t.Errorf("GetFeatureReport() error = %v, want ErrReportTooLarge without feature reports", err)
+	}
+}
+
+func TestDeviceNotificationEndsDevice(t *testing.T) {
+	device := &Device{}
+	cbID := uintptr(cmDeviceSeq.Add(1))
+	cmDevices.Store(cbID, device)
+	t.Cleanup(func() {
+		cmDevices.Delete(cbID)
+	})
+
+	const queryRemove _CM_NOTIFY_ACTION = 0x00000002
+	cmDeviceNotificationCallback(0, cbID, queryRemove, nil, 0)
+	if err := device.Err(); err != nil {
+		t.Fatalf("Err() after query remove = %v, want nil", err)
+	}
+
+	cmDeviceNotificationCallback(0, cbID, _CM_NOTIFY_ACTION_DEVICEREMOVECOMPLETE, nil, 0)
+	select {
+	case <-device.Done():
+	default:
+		t.Fatal("Done() is open after removal")
+	}
+	if err := device.Err(); !errors.Is(err, ErrDisconnected) {
+		t.Fatalf("Err() = %v, want ErrDisconnected", err)
+	}
+}
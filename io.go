@@ -0,0 +1,93 @@
+package hid
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// closeTimeout bounds how long Close waits for native calls that still use
+// the device after it tried to cancel them.
+var closeTimeout = 2 * time.Second
+
+// ioLock serializes operations of one kind like a mutex, but a caller that
+// waits for it gives up when its context is done and leaves nothing behind.
+// The zero value is unlocked.
+type ioLock struct {
+	once      sync.Once
+	semaphore chan struct{}
+}
+
+func (l *ioLock) channel() chan struct{} {
+	l.once.Do(func() {
+		l.semaphore = make(chan struct{}, 1)
+	})
+	return l.semaphore
+}
+
+// lock waits for the lock until ctx is done.
+func (l *ioLock) lock(ctx context.Context) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case l.channel() <- struct{}{}:
+	}
+
+	// Select picks at random when ctx was done as well.
+	if err := ctx.Err(); err != nil {
+		l.unlock()
+		return err
+	}
+	return nil
+}
+
+func (l *ioLock) unlock() {
+	<-l.channel()
+}
+
+// runIO serializes operations of one kind. The caller returns as soon as ctx
+// is done, whether it is still queued or the operation has started. A queued
+// caller leaves nothing behind, while a started operation keeps the lock in
+// its own goroutine until the native call returns, because cancellation
+// cannot stop it promptly. So at most one abandoned operation per lock is
+// outstanding.
+func runIO(ctx context.Context, l *ioLock, operation func() ioResult) ioResult {
+	if err := l.lock(ctx); err != nil {
+		return ioResult{err: err}
+	}
+
+	result := make(chan ioResult, 1)
+	go func() {
+		defer l.unlock()
+
+		result <- operation()
+	}()
+
+	select {
+	case <-ctx.Done():
+		return ioResult{err: ctx.Err()}
+
+	case r := <-result:
+		return r
+	}
+}
+
+// releaseWhenIdle runs release once wait returns, so that the device is not
+// released under a native call. It waits for release up to closeTimeout and
+// reports whether release ran. Otherwise a call is stuck in the driver, and
+// release runs in the background once it returns.
+func releaseWhenIdle(wait, release func()) bool {
+	released := make(chan struct{})
+	go func() {
+		wait()
+		release()
+		close(released)
+	}()
+
+	select {
+	case <-released:
+		return true
+	case <-time.After(closeTimeout):
+		return false
+	}
+}
@@ -5,59 +5,90 @@ package hid
 import (
 	"context"
 	"errors"
-	"sync"
+	"os"
+	"time"
 
 	"golang.org/x/sys/unix"
 )
 
-const linuxPollTimeout = 50
+// linuxCanceledDeadline is the read deadline that interrupts a pending read
+// when its context is done.
+var linuxCanceledDeadline = time.Unix(1, 0)
 
-func waitLinuxReadable(ctx context.Context, fd int) error {
-	pollFDs := []unix.PollFd{{
-		Fd:     int32(fd),
-		Events: unix.POLLIN,
-	}}
+// readFile reads from file, which must be in non-blocking mode, while the
+// Go netpoller waits for it to become readable. The reader costs nothing while
+// it waits, and cancellation of ctx interrupts it immediately through the read
+// deadline of file.
+func readFile(ctx context.Context, file *os.File, b []byte) (int, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
 
-	for {
-		if err := ctx.Err(); err != nil {
-			return err
-		}
+	conn, err := file.SyscallConn()
+	if err != nil {
+		return 0, err
+	}
 
-		ready, err := unix.Poll(pollFDs, linuxPollTimeout)
-		if errors.Is(err, unix.EINTR) {
-			continue
-		}
-		if err != nil {
-			return err
-		}
-		if ready > 0 {
-			return nil
+	canceled := make(chan struct{})
+	stop := context.AfterFunc(ctx, func() {
+		_ = file.SetReadDeadline(linuxCanceledDeadline)
+		close(canceled)
+	})
+
+	var n int
+	var readErr error
+	err = conn.Read(func(fd uintptr) bool {
+		n, readErr = unix.Read(int(fd), b)
+
+		return !errors.Is(readErr, unix.EAGAIN)
+	})
+
+	if !stop() {
+		<-canceled
+		_ = file.SetReadDeadline(time.Time{})
+		if errors.Is(err, os.ErrDeadlineExceeded) {
+			return 0, ctx.Err()
 		}
 	}
-}
+	if err != nil {
+		return 0, err
+	}
+	if readErr != nil {
+		return 0, readErr
+	}
 
-// runIO serializes operations of one kind. The mutex remains held by the
-// operation goroutine when cancellation cannot stop the native call promptly.
-func runIO(ctx context.Context, mu *sync.Mutex, operation func() ioResult) ioResult {
-	mu.Lock()
+	return n, nil
+}
 
-	if err := ctx.Err(); err != nil {
-		mu.Unlock()
+// controlDevice calls f with the descriptor of d. Unlike File.Fd, it leaves
+// the descriptor in non-blocking mode, which readFile relies on.
+func controlDevice[T any](d *Device, f func(fd int) (T, error)) (T, error) {
+	var v T
+	var fErr error
 
-		return ioResult{err: err}
+	conn, err := d.file.SyscallConn()
+	if err != nil {
+		return v, d.ioError(err)
+	}
+	if err := conn.Control(func(fd uintptr) {
+		v, fErr = f(int(fd))
+	}); err != nil {
+		return v, d.ioError(err)
 	}
 
-	result := make(chan ioResult, 1)
-	go func() {
-		defer mu.Unlock()
-		result <- operation()
-	}()
-
-	select {
-	case <-ctx.Done():
-		return ioResult{err: ctx.Err()}
+	return v, d.ioError(fErr)
+}
 
-	case r := <-result:
-		return r
+// linuxError wraps err with the sentinel error that its errno stands for.
+func linuxError(err error) error {
+	switch {
+	case errors.Is(err, unix.ENOENT):
+		return wrapError(ErrDeviceNotFound, err)
+	case errors.Is(err, unix.EACCES), errors.Is(err, unix.EPERM):
+		return wrapError(ErrPermission, err)
+	case errors.Is(err, unix.ENODEV), errors.Is(err, unix.ENXIO), errors.Is(err, unix.ESHUTDOWN):
+		return wrapError(ErrDisconnected, err)
 	}
+
+	return err
 }
@@ -6,8 +6,8 @@ import (
 	"context"
 	"errors"
 	"os"
+	"path/filepath"
 	"sync"
-	"sync/atomic"
 	"testing"
 	"time"
 
@@ -57,85 +57,311 @@ func TestDeviceReadCancellationLeavesReadUsable(t *testing.T) {
 	}
 }
 
-func TestRunIOSuccess(t *testing.T) {
-	var mu sync.Mutex
-	result := runIO(context.Background(), &mu, func() ioResult {
-		return ioResult{n: 3}
-	})
-	if result.err != nil {
-		t.Fatal(result.err)
+func TestDeviceReadWaitsForData(t *testing.T) {
+	var pipe [2]int
+	if err := unix.Pipe2(pipe[:], unix.O_CLOEXEC|unix.O_NONBLOCK); err != nil {
+		t.Fatal(err)
+	}
+	device := &Device{file: os.NewFile(uintptr(pipe[0]), "test pipe")}
+	defer func() {
+		_ = device.Close()
+		_ = unix.Close(pipe[1])
+	}()
+
+	type readResult struct {
+		n   int
+		err error
 	}
-	if result.n != 3 {
-		t.Fatalf("result = %d bytes, want 3", result.n)
+	buffer := make([]byte, 2)
+	done := make(chan readResult, 1)
+	go func() {
+		n, err := device.Read(context.Background(), buffer)
+		done <- readResult{n, err}
+	}()
+
+	select {
+	case r := <-done:
+		t.Fatalf("Read() = %d, %v before data arrived", r.n, r.err)
+	case <-time.After(10 * time.Millisecond):
+	}
+
+	if _, err := unix.Write(pipe[1], []byte{1, 2}); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case r := <-done:
+		if r.err != nil {
+			t.Fatal(r.err)
+		}
+		if r.n != 2 || buffer[0] != 1 || buffer[1] != 2 {
+			t.Fatalf("Read() = %d bytes %v, want [1 2]", r.n, buffer)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("read did not return after data arrived")
 	}
 }
 
-func TestRunIOCancellationReturnsPromptly(t *testing.T) {
-	var mu sync.Mutex
+func TestDeviceIoctlKeepsReadCancellable(t *testing.T) {
+	var pipe [2]int
+	if err := unix.Pipe2(pipe[:], unix.O_CLOEXEC|unix.O_NONBLOCK); err != nil {
+		t.Fatal(err)
+	}
+	device := &Device{file: os.NewFile(uintptr(pipe[0]), "test pipe")}
+	defer func() {
+		_ = device.Close()
+		_ = unix.Close(pipe[1])
+	}()
+
+	if _, err := device.RawName(); !errors.Is(err, unix.ENOTTY) {
+		t.Fatalf("RawName() error = %v, want ENOTTY", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	done := make(chan error, 1)
+	go func() {
+		_, err := device.Read(ctx, make([]byte, 1))
+		done <- err
+	}()
+
+	select {
+	case err := <-done:
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Fatalf("error = %v, want context.DeadlineExceeded", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("read did not stop at the context deadline")
+	}
+}
+
+func TestDeviceCloseUnblocksRead(t *testing.T) {
+	var pipe [2]int
+	if err := unix.Pipe2(pipe[:], unix.O_CLOEXEC|unix.O_NONBLOCK); err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = unix.Close(pipe[1]) }()
+	device := &Device{file: os.NewFile(uintptr(pipe[0]), "test pipe")}
+
 	started := make(chan struct{})
-	release := make(chan struct{})
+	done := make(chan error, 1)
+	go func() {
+		close(started)
+		_, err := device.Read(context.Background(), make([]byte, 1))
+		done <- err
+	}()
 
-	ctx, cancel := context.WithCancel(context.Background())
-	done := make(chan ioResult, 1)
+	<-started
+	time.Sleep(10 * time.Millisecond)
+	if err := device.Close(); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case err := <-done:
+		if !errors.Is(err, ErrClosed) {
+			t.Fatalf("error = %v, want ErrClosed", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("read did not return after Close")
+	}
+
+	if _, err := device.Read(context.Background(), make([]byte, 1)); !errors.Is(err, ErrClosed) {
+		t.Fatalf("Read() after Close error = %v, want ErrClosed", err)
+	}
+}
+
+func TestDeviceCloseUnblocksWrite(t *testing.T) {
+	var pipe [2]int
+	if err := unix.Pipe2(pipe[:], unix.O_CLOEXEC|unix.O_NONBLOCK); err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = unix.Close(pipe[0]) }()
+	chunk := make([]byte, 4096)
+	for {
+		if _, err := unix.Write(pipe[1], chunk); errors.Is(err, unix.EAGAIN) {
+			break
+		} else if err != nil {
+			t.Fatal(err)
+		}
+	}
+	device := &Device{file: os.NewFile(uintptr(pipe[1]), "test pipe")}
+
+	started := make(chan struct{})
+	done := make(chan error, 1)
 	go func() {
-		done <- runIO(ctx, &mu, func() ioResult {
-			close(started)
-			<-release
-			return ioResult{}
-		})
+		close(started)
+		_, err := device.Write(context.Background(), chunk)
+		done <- err
 	}()
 
 	<-started
-	cancel()
+	time.Sleep(10 * time.Millisecond)
+	if err := device.Close(); err != nil {
+		t.Fatal(err)
+	}
 	select {
-	case result := <-done:
-		if !errors.Is(result.err, context.Canceled) {
-			t.Fatalf("error = %v, want context.Canceled", result.err)
+	case err := <-done:
+		if !errors.Is(err, ErrClosed) {
+			t.Fatalf("error = %v, want ErrClosed", err)
 		}
 	case <-time.After(time.Second):
-		t.Fatal("operation did not return after cancellation")
+		t.Fatal("write did not return after Close")
 	}
 
-	close(release)
+	if _, err := device.Write(context.Background(), chunk); !errors.Is(err, ErrClosed) {
+		t.Fatalf("Write() after Close error = %v, want ErrClosed", err)
+	}
 }
 
-func TestRunIODeadlineExceeded(t *testing.T) {
-	var mu sync.Mutex
-	release := make(chan struct{})
-	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond)
-	defer cancel()
+func TestDeviceConcurrentClose(t *testing.T) {
+	var pipe [2]int
+	if err := unix.Pipe2(pipe[:], unix.O_CLOEXEC|unix.O_NONBLOCK); err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = unix.Close(pipe[1]) }()
+	device := &Device{file: os.NewFile(uintptr(pipe[0]), "test pipe")}
+
+	var wg sync.WaitGroup
+	for range 4 {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			if _, err := device.Read(context.Background(), make([]byte, 1)); !errors.Is(err, ErrClosed) {
+				t.Errorf("Read() error = %v, want ErrClosed", err)
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			_, _ = device.RawName()
+		}()
+	}
+
+	time.Sleep(10 * time.Millisecond)
+	_ = device.Close()
+	wg.Wait()
+}
 
-	result := runIO(ctx, &mu, func() ioResult {
-		<-release
-		return ioResult{}
-	})
-	if !errors.Is(result.err, context.DeadlineExceeded) {
-		t.Fatalf("error = %v, want context.DeadlineExceeded", result.err)
+func TestLinuxError(t *testing.T) {
+	tests := []struct {
+		err  error
+		want error
+	}{
+		{unix.ENOENT, ErrDeviceNotFound},
+		{unix.EACCES, ErrPermission},
+		{unix.EPERM, ErrPermission},
+		{unix.ENODEV, ErrDisconnected},
+		{unix.ENXIO, ErrDisconnected},
+		{unix.ESHUTDOWN, ErrDisconnected},
+		{&os.PathError{Op: "open", Path: "/dev/hidraw0", Err: unix.ENODEV}, ErrDisconnected},
+	}
+	for _, test := range tests {
+		err := linuxError(test.err)
+		if !errors.Is(err, test.want) {
+			t.Errorf("linuxError(%v) = %v, want %v", test.err, err, test.want)
+		}
+		if !errors.Is(err, test.err) {
+			t.Errorf("linuxError(%v) = %v, does not match the errno", test.err, err)
+		}
+	}
+
+	if err := linuxError(unix.ENOTTY); err != unix.ENOTTY {
+		t.Errorf("linuxError(ENOTTY) = %v, want ENOTTY", err)
 	}
-	close(release)
 }
 
-func TestRunIODoesNotStartAfterCancellationWhileQueued(t *testing.T) {
-	var mu sync.Mutex
-	var calls atomic.Int32
-	mu.Lock()
+func TestOpenPathNotFound(t *testing.T) {
+	_, err := OpenPath(filepath.Join(t.TempDir(), "hidraw0"))
+	if !errors.Is(err, ErrDeviceNotFound) {
+		t.Errorf("OpenPath() error = %v, want ErrDeviceNotFound", err)
+	}
+	if !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("OpenPath() error = %v, want os.ErrNotExist", err)
+	}
+}
 
-	ctx, cancel := context.WithCancel(context.Background())
-	done := make(chan ioResult, 1)
-	go func() {
-		done <- runIO(ctx, &mu, func() ioResult {
-			calls.Add(1)
-			return ioResult{}
-		})
-	}()
+func TestDeviceReportTooLarge(t *testing.T) {
+	file, err := os.Create(filepath.Join(t.TempDir(), "hidraw0"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	device := &Device{file: file}
+	defer device.Close()
 
-	cancel()
-	mu.Unlock()
-	result := <-done
-	if !errors.Is(result.err, context.Canceled) {
-		t.Fatalf("error = %v, want context.Canceled", result.err)
+	if _, err := device.Write(context.Background(), make([]byte, hidrawMaxBufferSize+1)); !errors.Is(err, ErrReportTooLarge) {
+		t.Errorf("Write() error = %v, want ErrReportTooLarge", err)
+	}
+	if err := device.SendFeatureReport(make([]byte, hidIOCMaxLength+1)); !errors.Is(err, ErrReportTooLarge) {
+		t.Errorf("SendFeatureReport() error = %v, want ErrReportTooLarge", err)
+	}
+	if _, err := device.GetInputReport(make([]byte, hidIOCMaxLength+1)); !errors.Is(err, ErrReportTooLarge) {
+		t.Errorf("GetInputReport() error = %v, want ErrReportTooLarge", err)
+	}
+}
+
+func TestDeviceDoneOnHangUp(t *testing.T) {
+	var pipe [2]int
+	if err := unix.Pipe2(pipe[:], unix.O_CLOEXEC|unix.O_NONBLOCK); err != nil {
+		t.Fatal(err)
+	}
+	device, err := newDevice(os.NewFile(uintptr(pipe[0]), "test pipe"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer device.Close()
+
+	// A report neither ends the device nor is consumed by the watcher.
+	if _, err := unix.Write(pipe[1], []byte{1}); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case <-device.Done():
+		t.Fatalf("Done() closed after a report, Err() = %v", device.Err())
+	case <-time.After(10 * time.Millisecond):
+	}
+	if err := device.Err(); err != nil {
+		t.Fatalf("Err() = %v, want nil", err)
+	}
+	buffer := make([]byte, 1)
+	if n, err := device.Read(context.Background(), buffer); err != nil || n != 1 || buffer[0] != 1 {
+		t.Fatalf("Read() = %d, %v, %v, want the report", n, err, buffer)
+	}
+
+	_ = unix.Close(pipe[1])
+	select {
+	case <-device.Done():
+	case <-time.After(time.Second):
+		t.Fatal("Done() was not closed after the hang-up")
+	}
+	if err := device.Err(); !errors.Is(err, ErrDisconnected) {
+		t.Fatalf("Err() = %v, want ErrDisconnected", err)
+	}
+	if err := device.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := device.Err(); !errors.Is(err, ErrDisconnected) {
+		t.Fatalf("Err() after Close = %v, want ErrDisconnected", err)
+	}
+}
+
+func TestDeviceDoneOnClose(t *testing.T) {
+	var pipe [2]int
+	if err := unix.Pipe2(pipe[:], unix.O_CLOEXEC|unix.O_NONBLOCK); err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = unix.Close(pipe[1]) }()
+	device, err := newDevice(os.NewFile(uintptr(pipe[0]), "test pipe"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := device.Close(); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case <-device.Done():
+	default:
+		t.Fatal("Done() is open after Close")
 	}
-	if calls.Load() != 0 {
-		t.Fatal("operation started after cancellation while queued")
+	if err := device.Err(); !errors.Is(err, ErrClosed) {
+		t.Fatalf("Err() = %v, want ErrClosed", err)
 	}
 }
@@ -0,0 +1,191 @@
+package hid
+
+import (
+	"context"
+	"errors"
+	"runtime"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRunIOSuccess(t *testing.T) {
+	var mu ioLock
+	result := runIO(context.Background(), &mu, func() ioResult {
+		return ioResult{n: 3}
+	})
+	if result.err != nil {
+		t.Fatal(result.err)
+	}
+	if result.n != 3 {
+		t.Fatalf("result = %d bytes, want 3", result.n)
+	}
+}
+
+func TestRunIOCancellationReturnsPromptly(t *testing.T) {
+	var mu ioLock
+	started := make(chan struct{})
+	release := make(chan struct{})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan ioResult, 1)
+	go func() {
+		done <- runIO(ctx, &mu, func() ioResult {
+			close(started)
+			<-release
+			return ioResult{}
+		})
+	}()
+
+	<-started
+	cancel()
+	select {
+	case result := <-done:
+		if !errors.Is(result.err, context.Canceled) {
+			t.Fatalf("error = %v, want context.Canceled", result.err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("operation did not return after cancellation")
+	}
+
+	close(release)
+}
+
+func TestRunIODeadlineExceeded(t *testing.T) {
+	var mu ioLock
+	release := make(chan struct{})
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond)
+	defer cancel()
+
+	result := runIO(ctx, &mu, func() ioResult {
+		<-release
+		return ioResult{}
+	})
+	if !errors.Is(result.err, context.DeadlineExceeded) {
+		t.Fatalf("error = %v, want context.DeadlineExceeded", result.err)
+	}
+	close(release)
+}
+
+func TestRunIODoesNotStartAfterCancellationWhileQueued(t *testing.T) {
+	var mu ioLock
+	var calls atomic.Int32
+	if err := mu.lock(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan ioResult, 1)
+	go func() {
+		done <- runIO(ctx, &mu, func() ioResult {
+			calls.Add(1)
+			return ioResult{}
+		})
+	}()
+
+	cancel()
+	result := <-done
+	mu.unlock()
+	if !errors.Is(result.err, context.Canceled) {
+		t.Fatalf("error = %v, want context.Canceled", result.err)
+	}
+	if calls.Load() != 0 {
+		t.Fatal("operation started after cancellation while queued")
+	}
+}
+
+func TestRunIOCancellationWhileQueued(t *testing.T) {
+	var mu ioLock
+	if err := mu.lock(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	defer mu.unlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	done := make(chan ioResult, 1)
+	go func() {
+		done <- runIO(ctx, &mu, func() ioResult {
+			return ioResult{}
+		})
+	}()
+
+	select {
+	case result := <-done:
+		if !errors.Is(result.err, context.DeadlineExceeded) {
+			t.Fatalf("error = %v, want context.DeadlineExceeded", result.err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("queued operation did not return after cancellation")
+	}
+}
+
+func TestRunIOCanceledWhileQueuedLeavesNoGoroutine(t *testing.T) {
+	var mu ioLock
+	if err := mu.lock(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	before := runtime.NumGoroutine()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	const callers = 100
+	done := make(chan ioResult, callers)
+	for range callers {
+		go func() {
+			done <- runIO(ctx, &mu, func() ioResult {
+				return ioResult{}
+			})
+		}()
+	}
+	cancel()
+	for range callers {
+		if result := <-done; !errors.Is(result.err, context.Canceled) {
+			t.Fatalf("error = %v, want context.Canceled", result.err)
+		}
+	}
+
+	// The callers have returned and must not have left waiters behind.
+	deadline := time.Now().Add(time.Second)
+	for runtime.NumGoroutine() > before && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	if n := runtime.NumGoroutine(); n > before {
+		t.Fatalf("%d goroutines after canceled callers, want at most %d", n, before)
+	}
+
+	mu.unlock()
+	if err := mu.lock(context.Background()); err != nil {
+		t.Fatalf("lock() after the canceled callers: %v", err)
+	}
+}
+
+func TestReleaseWhenIdle(t *testing.T) {
+	var released atomic.Bool
+	if !releaseWhenIdle(func() {}, func() { released.Store(true) }) || !released.Load() {
+		t.Fatal("releaseWhenIdle() did not release an idle device")
+	}
+}
+
+func TestReleaseWhenIdleReturnsDuringStuckCall(t *testing.T) {
+	originalTimeout := closeTimeout
+	t.Cleanup(func() { closeTimeout = originalTimeout })
+	closeTimeout = 50 * time.Millisecond
+
+	stuck := make(chan struct{})
+	released := make(chan struct{})
+	if releaseWhenIdle(func() { <-stuck }, func() { close(released) }) {
+		t.Fatal("releaseWhenIdle() = true during a stuck call")
+	}
+	select {
+	case <-released:
+		t.Fatal("device released during a stuck call")
+	default:
+	}
+
+	close(stuck)
+	select {
+	case <-released:
+	case <-time.After(time.Second):
+		t.Fatal("device not released after the stuck call returned")
+	}
+}
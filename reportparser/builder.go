@@ -0,0 +1,258 @@
+package reportparser
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+)
+
+var ErrUnencodableItem = errors.New("reportparser: item cannot be encoded")
+
+// Bytes encodes the items as a report descriptor. Every short item uses the
+// smallest data size that ParseItems decodes back to the same value, so
+// ParseItems(items.Bytes()) returns items.
+func (items Items) Bytes() ([]byte, error) {
+	var b []byte
+	for i, item := range items {
+		var err error
+		b, err = appendItem(b, item)
+		if err != nil {
+			return nil, fmt.Errorf("item %d: %w", i, err)
+		}
+	}
+	return b, nil
+}
+
+func appendItem(b []byte, item any) ([]byte, error) {
+	switch e := item.(type) {
+	case UsagePage:
+		return appendUintItem(b, e.Tag(), uint32(e)), nil
+	case LogicalMinimum:
+		return appendIntItem(b, e.Tag(), int32(e)), nil
+	case LogicalMaximum:
+		return appendIntItem(b, e.Tag(), int32(e)), nil
+	case PhysicalMinimum:
+		return appendIntItem(b, e.Tag(), int32(e)), nil
+	case PhysicalMaximum:
+		return appendIntItem(b, e.Tag(), int32(e)), nil
+	case UnitExponent:
+		return appendUnitExponent(b, int32(e))
+	case Unit:
+		return appendUintItem(b, e.Tag(), uint32(e)), nil
+	case ReportSize:
+		return appendUintItem(b, e.Tag(), uint32(e)), nil
+	case ReportID:
+		return appendUintItem(b, e.Tag(), uint32(e)), nil
+	case ReportCount:
+		return appendUintItem(b, e.Tag(), uint32(e)), nil
+	case Push:
+		return appendUintItem(b, e.Tag(), 0), nil
+	case Pop:
+		return appendUintItem(b, e.Tag(), 0), nil
+	case Usage:
+		return appendUintItem(b, e.Tag(), uint32(e)), nil
+	case UsageMinimum:
+		return appendUintItem(b, e.Tag(), uint32(e)), nil
+	case UsageMaximum:
+		return appendUintItem(b, e.Tag(), uint32(e)), nil
+	case DesignatorIndex:
+		return appendUintItem(b, e.Tag(), uint32(e)), nil
+	case DesignatorMinimum:
+		return appendUintItem(b, e.Tag(), uint32(e)), nil
+	case DesignatorMaximum:
+		return appendUintItem(b, e.Tag(), uint32(e)), nil
+	case StringIndex:
+		return appendUintItem(b, e.Tag(), uint32(e)), nil
+	case StringMinimum:
+		return appendUintItem(b, e.Tag(), uint32(e)), nil
+	case StringMaximum:
+		return appendUintItem(b, e.Tag(), uint32(e)), nil
+	case Delimiter:
+		return appendUintItem(b, e.Tag(), uint32(e)), nil
+	case Input:
+		return appendUintItem(b, e.Tag(), uint32(e)), nil
+	case Output:
+		return appendUintItem(b, e.Tag(), uint32(e)), nil
+	case Feature:
+		return appendUintItem(b, e.Tag(), uint32(e)), nil
+	case Collection:
+		return appendUintItem(b, e.Tag(), uint32(e)), nil
+	case EndCollection:
+		return appendUintItem(b, e.Tag(), 0), nil
+	case LongItem:
+		if len(e.Data) > math.MaxUint8 {
+			return nil, fmt.Errorf("%w: long item data is %d bytes", ErrUnencodableItem, len(e.Data))
+		}
+		b = append(b, longItemPrefix, byte(len(e.Data)), e.LongTag)
+		return append(b, e.Data...), nil
+	default:
+		return nil, fmt.Errorf("%w: %T", ErrUnencodableItem, item)
+	}
+}
+
+func appendUintItem(b []byte, tag ItemTag, v uint32) []byte {
+	switch {
+	case v == 0:
+		return appendShortItem(b, tag, ItemSize0, 0)
+	case v <= math.MaxUint8:
+		return appendShortItem(b, tag, ItemSize8, v)
+	case v <= math.MaxUint16:
+		return appendShortItem(b, tag, ItemSize16, v)
+	default:
+		return appendShortItem(b, tag, ItemSize32, v)
+	}
+}
+
+func appendIntItem(b []byte, tag ItemTag, v int32) []byte {
+	switch {
+	case v == 0:
+		return appendShortItem(b, tag, ItemSize0, 0)
+	case v >= math.MinInt8 && v <= math.MaxInt8:
+		return appendShortItem(b, tag, ItemSize8, uint32(v))
+	case v >= math.MinInt16 && v <= math.MaxInt16:
+		return appendShortItem(b, tag, ItemSize16, uint32(v))
+	default:
+		return appendShortItem(b, tag, ItemSize32, uint32(v))
+	}
+}
+
+// appendUnitExponent encodes exponents from -8 to 7 as the 4-bit nibble of
+// HID 1.11. Exponents from 8 to 15 cannot be encoded because parseUnitExponent
+// reads them back as nibbles.
+func appendUnitExponent(b []byte, v int32) ([]byte, error) {
+	switch {
+	case v >= -8 && v <= 7:
+		return appendUintItem(b, ItemTagGlobalUnitExponent, uint32(v)&0xf), nil
+	case v >= 8 && v <= 15:
+		return nil, fmt.Errorf("%w: unit exponent %d", ErrUnencodableItem, v)
+	default:
+		return appendIntItem(b, ItemTagGlobalUnitExponent, v), nil
+	}
+}
+
+func appendShortItem(b []byte, tag ItemTag, size ItemSize, v uint32) []byte {
+	b = append(b, byte(tag)<<2|byte(size))
+	switch size {
+	case ItemSize8:
+		b = append(b, byte(v))
+	case ItemSize16:
+		b = binary.LittleEndian.AppendUint16(b, uint16(v))
+	case ItemSize32:
+		b = binary.LittleEndian.AppendUint32(b, v)
+	}
+	return b
+}
+
+// Builder assembles the items of a report descriptor. Its methods append one
+// item each and return the builder so that calls can be chained:
+//
+//	b := reportparser.NewBuilder().
+//		UsagePage(0x01).
+//		Usage(0x02).
+//		Collection(reportparser.CollectionItemTypeApplication, func(b *reportparser.Builder) {
+//			b.ReportSize(8).ReportCount(3).Input(reportparser.InputFlagVariable)
+//		})
+//	descriptor, err := b.Bytes()
+type Builder struct {
+	items Items
+}
+
+func NewBuilder() *Builder {
+	return &Builder{}
+}
+
+// Add appends items, which must be values of the item types of this package.
+func (b *Builder) Add(items ...any) *Builder {
+	b.items = append(b.items, items...)
+	return b
+}
+
+// Items returns the items appended so far.
+func (b *Builder) Items() Items {
+	return b.items
+}
+
+// Bytes encodes the items appended so far.
+func (b *Builder) Bytes() ([]byte, error) {
+	return b.items.Bytes()
+}
+
+func (b *Builder) UsagePage(page uint16) *Builder {
+	return b.Add(UsagePage(page))
+}
+
+func (b *Builder) LogicalMinimum(v int32) *Builder {
+	return b.Add(LogicalMinimum(v))
+}
+
+func (b *Builder) LogicalMaximum(v int32) *Builder {
+	return b.Add(LogicalMaximum(v))
+}
+
+func (b *Builder) PhysicalMinimum(v int32) *Builder {
+	return b.Add(PhysicalMinimum(v))
+}
+
+func (b *Builder) PhysicalMaximum(v int32) *Builder {
+	return b.Add(PhysicalMaximum(v))
+}
+
+func (b *Builder) UnitExponent(v int32) *Builder {
+	return b.Add(UnitExponent(v))
+}
+
+func (b *Builder) Unit(u Unit) *Builder {
+	return b.Add(u)
+}
+
+func (b *Builder) ReportSize(size uint32) *Builder {
+	return b.Add(ReportSize(size))
+}
+
+func (b *Builder) ReportID(id uint8) *Builder {
+	return b.Add(ReportID(id))
+}
+
+func (b *Builder) ReportCount(count uint32) *Builder {
+	return b.Add(ReportCount(count))
+}
+
+func (b *Builder) Push() *Builder {
+	return b.Add(Push{})
+}
+
+func (b *Builder) Pop() *Builder {
+	return b.Add(Pop{})
+}
+
+// Usage appends a usage ID, or an extended usage when the upper 16 bits are
+// set.
+func (b *Builder) Usage(usage uint32) *Builder {
+	return b.Add(Usage(usage))
+}
+
+// UsageRange appends a Usage Minimum and Usage Maximum.
+func (b *Builder) UsageRange(minimum, maximum uint32) *Builder {
+	return b.Add(UsageMinimum(minimum), UsageMaximum(maximum))
+}
+
+func (b *Builder) Input(flags InputFlags) *Builder {
+	return b.Add(Input(flags))
+}
+
+func (b *Builder) Output(flags OutputFlags) *Builder {
+	return b.Add(Output(flags))
+}
+
+func (b *Builder) Feature(flags FeatureFlags) *Builder {
+	return b.Add(Feature(flags))
+}
+
+// Collection appends a collection of typ whose items are appended by fn,
+// followed by its End Collection.
+func (b *Builder) Collection(typ CollectionItemType, fn func(*Builder)) *Builder {
+	b.Add(Collection(typ))
+	fn(b)
+	return b.Add(EndCollection{})
+}
@@ -0,0 +1,104 @@
+package reportparser
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestItemsBytes(t *testing.T) {
+	tests := []struct {
+		name string
+		item any
+		want []byte
+	}{
+		{"zero value", Collection(CollectionItemTypePhysical), []byte{0xa0}},
+		{"unsigned 8-bit", ReportCount(64), []byte{0x95, 0x40}},
+		{"unsigned 16-bit", UsagePage(0xf1d0), []byte{0x06, 0xd0, 0xf1}},
+		{"extended usage", Usage(0x000c_00b5), []byte{0x0b, 0xb5, 0x00, 0x0c, 0x00}},
+		{"positive needs sign bit", LogicalMaximum(255), []byte{0x26, 0xff, 0x00}},
+		{"negative 8-bit", LogicalMinimum(-127), []byte{0x15, 0x81}},
+		{"negative 32-bit", PhysicalMinimum(-40000), []byte{0x37, 0xc0, 0x63, 0xff, 0xff}},
+		{"unit exponent nibble", UnitExponent(-2), []byte{0x55, 0x0e}},
+		{"unit exponent beyond nibble", UnitExponent(-9), []byte{0x55, 0xf7}},
+		{"end collection", EndCollection{}, []byte{0xc0}},
+		{"long item", LongItem{LongTag: 0xf0, Data: []byte{0xaa}}, []byte{0xfe, 0x01, 0xf0, 0xaa}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := Items{test.item}.Bytes()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(got, test.want) {
+				t.Fatalf("Bytes() = % x, want % x", got, test.want)
+			}
+			items, err := ParseItems(got)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(items, Items{test.item}) {
+				t.Fatalf("ParseItems(Bytes()) = %#v, want %#v", items, test.item)
+			}
+		})
+	}
+}
+
+func TestItemsBytesErrors(t *testing.T) {
+	for _, items := range []Items{
+		{UnitExponent(8)},
+		{LongItem{Data: make([]byte, 256)}},
+		{UsagePage(1), "Usage (Mouse)"},
+	} {
+		if _, err := items.Bytes(); !errors.Is(err, ErrUnencodableItem) {
+			t.Errorf("%#v.Bytes() error = %v, want %v", items, err, ErrUnencodableItem)
+		}
+	}
+}
+
+func TestBuilderMouse(t *testing.T) {
+	b := NewBuilder().
+		UsagePage(0x01).
+		Usage(0x02).
+		Collection(CollectionItemTypeApplication, func(b *Builder) {
+			b.ReportID(1).
+				Usage(0x01).
+				Collection(CollectionItemTypePhysical, func(b *Builder) {
+					b.UsagePage(0x09).
+						UsageRange(1, 3).
+						LogicalMinimum(0).
+						LogicalMaximum(1).
+						ReportCount(3).
+						ReportSize(1).
+						Input(InputFlagVariable).
+						ReportCount(1).
+						ReportSize(5).
+						Input(InputFlagConstant | InputFlagVariable).
+						UsagePage(0x01).
+						Usage(0x30).
+						Usage(0x31).
+						LogicalMinimum(-127).
+						LogicalMaximum(127).
+						ReportSize(8).
+						ReportCount(2).
+						Input(InputFlagVariable | InputFlagRelative)
+				})
+		})
+
+	got, err := b.Bytes()
+	if err != nil {
+		t.Fatal(err)
+	}
+	items, err := ParseItems(got)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(items, b.Items()) {
+		t.Fatalf("ParseItems(Bytes()) = %#v, want %#v", items, b.Items())
+	}
+	if want := ParseReport(mouseDescriptor); !reflect.DeepEqual(items, want) {
+		t.Fatalf("built items = %#v, want %#v", items, want)
+	}
+}
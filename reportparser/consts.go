@@ -1,4 +1,4 @@
-//go:generate stringer -type=ItemSize,ItemType,ItemTag,CollectionItemType,InputFlags,OutputFlags,FeatureFlags -output=consts_string.go
+//go:generate stringer -type=ItemSize,ItemType,ItemTag,CollectionItemType,InputFlags,OutputFlags,FeatureFlags,UnitSystem,ReportType -output=consts_string.go
 package reportparser
 
 type ItemSize uint8
@@ -10,6 +10,20 @@ const (
 	ItemSize32
 )
 
+// Len returns the number of data bytes that follow the item prefix.
+func (s ItemSize) Len() int {
+	switch s {
+	case ItemSize8:
+		return 1
+	case ItemSize16:
+		return 2
+	case ItemSize32:
+		return 4
+	default:
+		return 0
+	}
+}
+
 type ItemType uint8
 
 const (
@@ -51,6 +65,8 @@ const (
 	ItemTagLocalStringMinimum     ItemTag = 0b100010
 	ItemTagLocalStringMaximum     ItemTag = 0b100110
 	ItemTagLocalDelimiter         ItemTag = 0b101010
+
+	ItemTagLong ItemTag = 0b111111
 )
 
 func (t ItemTag) Type() ItemType {
@@ -123,3 +139,23 @@ const (
 	_
 	FeatureFlagBufferedBytes
 )
+
+type UnitSystem uint8
+
+const (
+	UnitSystemNone UnitSystem = iota
+	UnitSystemSILinear
+	UnitSystemSIRotation
+	UnitSystemEnglishLinear
+	UnitSystemEnglishRotation
+
+	UnitSystemVendor UnitSystem = 0xf
+)
+
+type ReportType uint8
+
+const (
+	ReportTypeInput ReportType = iota
+	ReportTypeOutput
+	ReportTypeFeature
+)
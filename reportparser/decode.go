@@ -0,0 +1,151 @@
+package reportparser
+
+import "fmt"
+
+// Value is the value of one element of a variable field.
+type Value struct {
+	Field *Field
+	Usage ExtendedUsage
+	Value int32
+}
+
+// Values holds the decoded fields of one report.
+type Values struct {
+	Report *Report
+	// Variables holds the elements of variable fields in report order.
+	// Elements in their null state are omitted.
+	Variables []Value
+	// Active holds the usages selected by array fields in report order.
+	Active []ExtendedUsage
+}
+
+// Get returns the value of the first variable element with usage.
+func (v *Values) Get(usage ExtendedUsage) (int32, bool) {
+	for _, value := range v.Variables {
+		if value.Usage == usage {
+			return value.Value, true
+		}
+	}
+	return 0, false
+}
+
+// IsActive reports whether an array field selected usage.
+func (v *Values) IsActive(usage ExtendedUsage) bool {
+	for _, active := range v.Active {
+		if active == usage {
+			return true
+		}
+	}
+	return false
+}
+
+// Decode decodes a report of typ. As Device.Read returns them, numbered
+// reports start with the report ID and unnumbered reports start with data.
+// Constant fields are skipped.
+func (l *Layout) Decode(typ ReportType, b []byte) (*Values, error) {
+	report, err := l.Find(typ, b)
+	if err != nil {
+		return nil, err
+	}
+	data := report.data(b)
+
+	values := &Values{Report: report}
+	for _, field := range report.Fields {
+		item := field.Item
+		if item.IsConstant() || item.ReportSize == 0 {
+			continue
+		}
+		if !field.fits(len(data)) {
+			return nil, fmt.Errorf("%w: field at bit %d does not fit in %d data bytes", ErrShortReport, field.BitOffset, len(data))
+		}
+
+		for i := range int(item.ReportCount) {
+			v := item.logicalValue(extractBits(data, field.BitOffset+i*int(item.ReportSize), int(item.ReportSize)))
+
+			if item.IsVariable() {
+				usage, ok := item.usage(i)
+				if !ok || (item.HasNullState() && !item.inLogicalRange(v)) {
+					continue
+				}
+				values.Variables = append(values.Variables, Value{Field: field, Usage: usage, Value: v})
+				continue
+			}
+
+			// An array element holds an index into the usages. Values outside
+			// the logical range and the reserved usage ID 0 select nothing.
+			if !item.inLogicalRange(v) {
+				continue
+			}
+			usage, ok := item.usage(int(int64(v) - int64(item.LogicalMinimum)))
+			if ok && usage.ID() != 0 {
+				values.Active = append(values.Active, usage)
+			}
+		}
+	}
+
+	return values, nil
+}
+
+// usage returns the usage of element i. Elements beyond the declared usages
+// share the last one.
+func (m *MainItem) usage(i int) (ExtendedUsage, bool) {
+	if len(m.Usages) == 0 || i < 0 {
+		return 0, false
+	}
+	if m.IsVariable() {
+		i = min(i, len(m.Usages)-1)
+	} else if i >= len(m.Usages) {
+		return 0, false
+	}
+	return m.Usages[i], true
+}
+
+// logicalValue sign-extends raw when the logical range is signed.
+func (m *MainItem) logicalValue(raw uint32) int32 {
+	if m.LogicalMinimum >= 0 || m.ReportSize >= 32 || m.ReportSize == 0 {
+		return int32(raw)
+	}
+	shift := 32 - m.ReportSize
+	return int32(raw<<shift) >> shift
+}
+
+func (m *MainItem) inLogicalRange(v int32) bool {
+	return v >= m.LogicalMinimum && v <= m.LogicalMaximum
+}
+
+// data returns the data bytes of b, without the report ID.
+func (r *Report) data(b []byte) []byte {
+	if r.ID != 0 {
+		return b[1:]
+	}
+	return b
+}
+
+// fits reports whether every element of the field lies within n data bytes.
+// It guards against a Layout that was built or modified by hand.
+func (f *Field) fits(n int) bool {
+	if f.BitOffset < 0 || f.BitOffset > n*8 {
+		return false
+	}
+	if f.Item.ReportCount == 0 {
+		return true
+	}
+	// Elements are ReportSize bits apart but at most 32 bits of each are
+	// read. The uint64 arithmetic cannot overflow for 32-bit operands.
+	size := uint64(f.Item.ReportSize)
+	end := (uint64(f.Item.ReportCount)-1)*size + min(size, 32)
+	return end <= uint64(n*8-f.BitOffset)
+}
+
+// extractBits reads size bits, up to 32, starting at bit offset. Fields are
+// packed least significant bit first.
+func extractBits(data []byte, offset, size int) uint32 {
+	var v uint32
+	for i := range min(size, 32) {
+		bit := offset + i
+		if data[bit/8]&(1<<(bit%8)) != 0 {
+			v |= 1 << i
+		}
+	}
+	return v
+}
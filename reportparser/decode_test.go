@@ -0,0 +1,233 @@
+package reportparser
+
+import (
+	"errors"
+	"slices"
+	"testing"
+)
+
+func TestDecodeVariables(t *testing.T) {
+	d, err := Parse(mouseDescriptor)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	l, err := d.Layout()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	values, err := l.Decode(ReportTypeInput, []byte{0x01, 0b11111_101, 0xfb, 0x0a})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if values.Report.ID != 1 {
+		t.Fatalf("report ID = %d, want 1", values.Report.ID)
+	}
+
+	for button, want := range []int32{1, 0, 1} {
+		if got, ok := values.Get(NewExtendedUsage(0x09, uint16(button+1))); !ok || got != want {
+			t.Fatalf("button %d = %d, %v; want %d", button+1, got, ok, want)
+		}
+	}
+	if got, ok := values.Get(NewExtendedUsage(0x01, 0x30)); !ok || got != -5 {
+		t.Fatalf("X = %d, %v; want -5", got, ok)
+	}
+	if got, ok := values.Get(NewExtendedUsage(0x01, 0x31)); !ok || got != 10 {
+		t.Fatalf("Y = %d, %v; want 10", got, ok)
+	}
+	if len(values.Variables) != 5 {
+		t.Fatalf("got %d variables, want 5: %+v", len(values.Variables), values.Variables)
+	}
+}
+
+func TestDecodeArrayAndNullState(t *testing.T) {
+	d, err := Parse([]byte{
+		0x05, 0x01, // Usage Page (Generic Desktop)
+		0x09, 0x05, // Usage (Game Pad)
+		0xa1, 0x01, // Collection (Application)
+		0x09, 0x39, //   Usage (Hat Switch)
+		0x15, 0x00, //   Logical Minimum (0)
+		0x25, 0x07, //   Logical Maximum (7)
+		0x75, 0x04, //   Report Size (4)
+		0x95, 0x01, //   Report Count (1)
+		0x81, 0x42, //   Input (Data, Variable, Absolute, Null State)
+		0x75, 0x04, //   Report Size (4)
+		0x81, 0x03, //   Input (Constant, Variable, Absolute)
+		0x05, 0x07, //   Usage Page (Keyboard/Keypad)
+		0x09, 0x00, //   Usage (Reserved)
+		0x09, 0x04, //   Usage (Keyboard A)
+		0x09, 0x05, //   Usage (Keyboard B)
+		0x09, 0x06, //   Usage (Keyboard C)
+		0x15, 0x00, //   Logical Minimum (0)
+		0x25, 0x03, //   Logical Maximum (3)
+		0x75, 0x08, //   Report Size (8)
+		0x95, 0x03, //   Report Count (3)
+		0x81, 0x00, //   Input (Data, Array, Absolute)
+		0xc0, // End Collection
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	l, err := d.Layout()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	values, err := l.Decode(ReportTypeInput, []byte{0x03, 0x02, 0x00, 0x09})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, ok := values.Get(NewExtendedUsage(0x01, 0x39)); !ok || got != 3 {
+		t.Fatalf("hat switch = %d, %v; want 3", got, ok)
+	}
+	if want := []ExtendedUsage{NewExtendedUsage(0x07, 0x05)}; !slices.Equal(values.Active, want) {
+		t.Fatalf("active usages = %v, want %v", values.Active, want)
+	}
+	if !values.IsActive(NewExtendedUsage(0x07, 0x05)) || values.IsActive(NewExtendedUsage(0x07, 0x04)) {
+		t.Fatal("IsActive does not match the active usages")
+	}
+
+	values, err = l.Decode(ReportTypeInput, []byte{0x08, 0x01, 0x03, 0x00})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := values.Get(NewExtendedUsage(0x01, 0x39)); ok {
+		t.Fatal("hat switch in its null state was reported")
+	}
+	if want := []ExtendedUsage{NewExtendedUsage(0x07, 0x04), NewExtendedUsage(0x07, 0x06)}; !slices.Equal(values.Active, want) {
+		t.Fatalf("active usages = %v, want %v", values.Active, want)
+	}
+
+	if _, err := l.Decode(ReportTypeInput, []byte{0x00}); !errors.Is(err, ErrShortReport) {
+		t.Fatalf("Decode() error = %v, want ErrShortReport", err)
+	}
+}
+
+func TestDecodeKeyArrayWithUnsignedMaximum(t *testing.T) {
+	d, err := Parse([]byte{
+		0x05, 0x01, // Usage Page (Generic Desktop)
+		0x09, 0x06, // Usage (Keyboard)
+		0xa1, 0x01, // Collection (Application)
+		0x05, 0x07, //   Usage Page (Keyboard/Keypad)
+		0x19, 0x00, //   Usage Minimum (0)
+		0x29, 0xff, //   Usage Maximum (255)
+		0x15, 0x00, //   Logical Minimum (0)
+		0x25, 0xff, //   Logical Maximum (255, encoded as -1)
+		0x75, 0x08, //   Report Size (8)
+		0x95, 0x06, //   Report Count (6)
+		0x81, 0x00, //   Input (Data, Array, Absolute)
+		0xc0, // End Collection
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	item := d.Items[0]
+	if item.LogicalMaximum != 255 || len(item.Usages) != 256 {
+		t.Fatalf("Logical Maximum = %d with %d usages, want 255 with 256", item.LogicalMaximum, len(item.Usages))
+	}
+	l, err := d.Layout()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	values, err := l.Decode(ReportTypeInput, []byte{0x04, 0xe0, 0, 0, 0, 0})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []ExtendedUsage{NewExtendedUsage(0x07, 0x04), NewExtendedUsage(0x07, 0xe0)}
+	if !slices.Equal(values.Active, want) {
+		t.Fatalf("active usages = %v, want %v", values.Active, want)
+	}
+
+	b, err := l.Encode(ReportTypeInput, 0, map[ExtendedUsage]int32{NewExtendedUsage(0x07, 0xe0): 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []byte{0x00, 0xe0, 0, 0, 0, 0, 0}; !slices.Equal(b, want) {
+		t.Fatalf("Encode() = %#v, want %#v", b, want)
+	}
+}
+
+// overflowDescriptor declares an input report of 4294967295 fields of
+// 4294967295 bits, which once overflowed the layout and panicked Decode.
+var overflowDescriptor = []byte{
+	0x05, 0x01, 0x09, 0x02, 0xa1, 0x01,
+	0x09, 0x30, 0x15, 0x00, 0x25, 0x01,
+	0x77, 0xff, 0xff, 0xff, 0xff, // Report Size (4294967295)
+	0x97, 0xff, 0xff, 0xff, 0xff, // Report Count (4294967295)
+	0x81, 0x02,
+	0xc0,
+}
+
+func TestDecodeOverflowingDescriptor(t *testing.T) {
+	d, err := Parse(overflowDescriptor)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := d.Layout(); !errors.Is(err, ErrInvalidReportSize) {
+		t.Fatalf("Layout() error = %v, want ErrInvalidReportSize", err)
+	}
+
+	// A hand-built layout must not make Decode read past the report.
+	item := d.Items[0]
+	l := &Layout{Reports: []*Report{{
+		Type:      ReportTypeInput,
+		BitLength: 8,
+		Fields:    []*Field{{Item: item, BitLength: 8}},
+	}}}
+	if _, err := l.Decode(ReportTypeInput, []byte{1, 2, 3}); !errors.Is(err, ErrShortReport) {
+		t.Fatalf("Decode() error = %v, want ErrShortReport", err)
+	}
+}
+
+func TestExtractBits(t *testing.T) {
+	data := []byte{0b1010_1100, 0b0000_0011, 0xff, 0xff, 0xff, 0xff}
+	tests := []struct {
+		offset, size int
+		want         uint32
+	}{
+		{0, 4, 0b1100},
+		{4, 4, 0b1010},
+		{6, 4, 0b1110},
+		{8, 2, 0b11},
+		{16, 32, 0xffffffff},
+	}
+	for _, test := range tests {
+		if got := extractBits(data, test.offset, test.size); got != test.want {
+			t.Errorf("extractBits(%d, %d) = %#b, want %#b", test.offset, test.size, got, test.want)
+		}
+	}
+}
+
+func FuzzDecode(f *testing.F) {
+	f.Add(mouseDescriptor, []byte{0x01, 0b11111_101, 0xfb, 0x0a})
+	f.Add(ledDescriptor, []byte{0x02, 0xd6, 0x03, 0x00})
+	f.Add(fidoDescriptor, make([]byte, 64))
+	f.Add(overflowDescriptor, []byte{1, 2, 3})
+	f.Fuzz(func(t *testing.T, descriptor, report []byte) {
+		d, err := Parse(descriptor)
+		if err != nil {
+			return
+		}
+		l, err := d.Layout()
+		if err != nil {
+			return
+		}
+		for _, typ := range []ReportType{ReportTypeInput, ReportTypeOutput, ReportTypeFeature} {
+			_, _ = l.Decode(typ, report)
+		}
+		for _, r := range l.Reports {
+			b, err := l.Encode(r.Type, r.ID, nil)
+			if err != nil {
+				t.Fatalf("Encode(%v, %d) error = %v", r.Type, r.ID, err)
+			}
+			if r.ID == 0 {
+				b = b[1:]
+			}
+			if _, err := l.Decode(r.Type, b); err != nil {
+				t.Fatalf("Decode(Encode(%v, %d)) error = %v", r.Type, r.ID, err)
+			}
+		}
+	})
+}
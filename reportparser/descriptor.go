@@ -0,0 +1,251 @@
+package reportparser
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+var (
+	ErrUnmatchedEndCollection = errors.New("reportparser: End Collection without matching Collection")
+	ErrUnclosedCollection     = errors.New("reportparser: Collection without matching End Collection")
+	ErrPopWithoutPush         = errors.New("reportparser: Pop without matching Push")
+)
+
+// Descriptor is a report descriptor resolved into its collection tree.
+type Descriptor struct {
+	// Collections holds the top-level collections in declaration order.
+	Collections []*CollectionNode
+	// Items holds every Input, Output and Feature item in declaration order,
+	// including items declared outside any collection.
+	Items []*MainItem
+}
+
+// CollectionNode is a collection together with the items it encloses.
+type CollectionNode struct {
+	Type        CollectionItemType
+	UsagePage   uint16
+	Usage       uint16
+	Parent      *CollectionNode
+	Collections []*CollectionNode
+	Items       []*MainItem
+}
+
+// MainItem is an Input, Output or Feature item with the global and local
+// state that was in effect when it was declared. As hosts do, a negative
+// Logical or Physical Maximum is read as unsigned when the matching minimum
+// is not negative, so that a maximum of 255 encoded as the byte FF is 255.
+type MainItem struct {
+	Tag             ItemTag
+	Flags           uint32
+	UsagePage       uint16
+	Usages          []ExtendedUsage
+	LogicalMinimum  int32
+	LogicalMaximum  int32
+	PhysicalMinimum int32
+	PhysicalMaximum int32
+	Unit            Unit
+	UnitExponent    int32
+	ReportID        uint8
+	ReportSize      uint32
+	ReportCount     uint32
+	Collection      *CollectionNode
+}
+
+// IsConstant reports whether the item is constant padding. The data flags share
+// bit positions across Input, Output and Feature items.
+func (m *MainItem) IsConstant() bool {
+	return m.Flags&uint32(InputFlagConstant) != 0
+}
+
+func (m *MainItem) IsVariable() bool {
+	return m.Flags&uint32(InputFlagVariable) != 0
+}
+
+func (m *MainItem) IsRelative() bool {
+	return m.Flags&uint32(InputFlagRelative) != 0
+}
+
+func (m *MainItem) HasNullState() bool {
+	return m.Flags&uint32(InputFlagNullState) != 0
+}
+
+// usageLimit returns the number of usages the item can refer to: one per
+// element of a variable item, one per logical value of an array item.
+func (m *MainItem) usageLimit() int {
+	n := int64(m.ReportCount)
+	if !m.IsVariable() {
+		n = int64(m.LogicalMaximum) - int64(m.LogicalMinimum) + 1
+	}
+	return int(min(max(n, 0), maxUsages))
+}
+
+// Descriptor resolves items into a collection tree.
+func (items Items) Descriptor() (*Descriptor, error) {
+	d, index, err := buildDescriptor(items)
+	if err != nil {
+		return nil, fmt.Errorf("item %d: %w", index, err)
+	}
+	return d, nil
+}
+
+// globalState holds the global items of the item state table. Push and Pop
+// save and restore it as a whole.
+type globalState struct {
+	usagePage       uint16
+	logicalMinimum  int32
+	logicalMaximum  int32
+	physicalMinimum int32
+	physicalMaximum int32
+	unit            Unit
+	unitExponent    int32
+	reportID        uint8
+	reportSize      uint32
+	reportCount     uint32
+}
+
+// localState holds the local items of the item state table. It only applies
+// to the next main item and is cleared after every main item.
+type localState struct {
+	// usages holds single usages and Usage Minimum/Maximum ranges in
+	// declaration order; a single usage is a range of one.
+	usages []usageRange
+
+	usageMinimum, usageMaximum       uint32
+	hasUsageMinimum, hasUsageMaximum bool
+}
+
+// descriptorState is the item state table in effect while walking items.
+type descriptorState struct {
+	global globalState
+	local  localState
+	stack  []globalState
+}
+
+// buildDescriptor returns the index of the offending item alongside an error.
+func buildDescriptor(items Items) (*Descriptor, int, error) {
+	d := &Descriptor{}
+	var state descriptorState
+	var current *CollectionNode
+
+	for index, item := range items {
+		switch e := item.(type) {
+		case UsagePage:
+			state.global.usagePage = e.Value()
+		case LogicalMinimum:
+			state.global.logicalMinimum = e.Value()
+		case LogicalMaximum:
+			state.global.logicalMaximum = e.Value()
+		case PhysicalMinimum:
+			state.global.physicalMinimum = e.Value()
+		case PhysicalMaximum:
+			state.global.physicalMaximum = e.Value()
+		case Unit:
+			state.global.unit = e
+		case UnitExponent:
+			state.global.unitExponent = e.Value()
+		case ReportID:
+			state.global.reportID = uint8(e)
+		case ReportSize:
+			state.global.reportSize = uint32(e)
+		case ReportCount:
+			state.global.reportCount = uint32(e)
+		case Push:
+			state.stack = append(state.stack, state.global)
+		case Pop:
+			if len(state.stack) == 0 {
+				return nil, index, ErrPopWithoutPush
+			}
+			state.global = state.stack[len(state.stack)-1]
+			state.stack = state.stack[:len(state.stack)-1]
+		case Usage:
+			state.local.usages = append(state.local.usages, usageRange{e.Value(), e.Value()})
+		case UsageMinimum:
+			state.local.usageMinimum, state.local.hasUsageMinimum = e.Value(), true
+			state.local.completeRange()
+		case UsageMaximum:
+			state.local.usageMaximum, state.local.hasUsageMaximum = e.Value(), true
+			state.local.completeRange()
+		case Input:
+			d.addMainItem(current, &state, ItemTagMainInput, uint32(e))
+		case Output:
+			d.addMainItem(current, &state, ItemTagMainOutput, uint32(e))
+		case Feature:
+			d.addMainItem(current, &state, ItemTagMainFeature, uint32(e))
+		case Collection:
+			node := &CollectionNode{
+				Type:      e.Value(),
+				UsagePage: state.global.usagePage,
+				Parent:    current,
+			}
+			if usages := state.local.resolve(state.global.usagePage, 1); len(usages) > 0 {
+				node.UsagePage = usages[0].Page()
+				node.Usage = usages[0].ID()
+			}
+			if current == nil {
+				d.Collections = append(d.Collections, node)
+			} else {
+				current.Collections = append(current.Collections, node)
+			}
+			current = node
+			state.local = localState{}
+		case EndCollection:
+			if current == nil {
+				return nil, index, ErrUnmatchedEndCollection
+			}
+			current = current.Parent
+			state.local = localState{}
+		}
+	}
+
+	if current != nil {
+		return nil, len(items), ErrUnclosedCollection
+	}
+
+	return d, 0, nil
+}
+
+func (d *Descriptor) addMainItem(current *CollectionNode, state *descriptorState, tag ItemTag, flags uint32) {
+	item := &MainItem{
+		Tag:             tag,
+		Flags:           flags,
+		UsagePage:       state.global.usagePage,
+		LogicalMinimum:  state.global.logicalMinimum,
+		LogicalMaximum:  unsignedMaximum(state.global.logicalMinimum, state.global.logicalMaximum),
+		PhysicalMinimum: state.global.physicalMinimum,
+		PhysicalMaximum: unsignedMaximum(state.global.physicalMinimum, state.global.physicalMaximum),
+		Unit:            state.global.unit,
+		UnitExponent:    state.global.unitExponent,
+		ReportID:        state.global.reportID,
+		ReportSize:      state.global.reportSize,
+		ReportCount:     state.global.reportCount,
+		Collection:      current,
+	}
+	item.Usages = state.local.resolve(state.global.usagePage, item.usageLimit())
+	state.local = localState{}
+
+	d.Items = append(d.Items, item)
+	if current != nil {
+		current.Items = append(current.Items, item)
+	}
+}
+
+// unsignedMaximum returns maximum read as an unsigned value when minimum is
+// not negative, as the Linux kernel and hidapi do. HID 1.11 defines extents
+// as signed, but devices commonly encode a maximum of 255 as the single byte
+// FF, which reads as -1. Items hold the sign-extended value, so the item size
+// taken is that of its shortest encoding, the one Items.Bytes writes.
+func unsignedMaximum(minimum, maximum int32) int32 {
+	if minimum < 0 || maximum >= 0 {
+		return maximum
+	}
+	switch {
+	case maximum >= math.MinInt8:
+		return int32(uint8(maximum))
+	case maximum >= math.MinInt16:
+		return int32(uint16(maximum))
+	default:
+		// A 32-bit unsigned maximum does not fit in an int32.
+		return maximum
+	}
+}
@@ -0,0 +1,270 @@
+package reportparser
+
+import (
+	"errors"
+	"slices"
+	"testing"
+)
+
+var fidoDescriptor = []byte{
+	0x06, 0xd0, 0xf1, // Usage Page (FIDO Alliance)
+	0x09, 0x01, // Usage (CTAPHID)
+	0xa1, 0x01, // Collection (Application)
+	0x09, 0x20, //   Usage (Input Report Data)
+	0x15, 0x00, //   Logical Minimum (0)
+	0x26, 0xff, 0x00, //   Logical Maximum (255)
+	0x75, 0x08, //   Report Size (8)
+	0x95, 0x40, //   Report Count (64)
+	0x81, 0x02, //   Input (Data, Variable, Absolute)
+	0x09, 0x21, //   Usage (Output Report Data)
+	0x15, 0x00, //   Logical Minimum (0)
+	0x26, 0xff, 0x00, //   Logical Maximum (255)
+	0x75, 0x08, //   Report Size (8)
+	0x95, 0x40, //   Report Count (64)
+	0x91, 0x02, //   Output (Data, Variable, Absolute)
+	0xc0, // End Collection
+}
+
+var mouseDescriptor = []byte{
+	0x05, 0x01, // Usage Page (Generic Desktop)
+	0x09, 0x02, // Usage (Mouse)
+	0xa1, 0x01, // Collection (Application)
+	0x85, 0x01, //   Report ID (1)
+	0x09, 0x01, //   Usage (Pointer)
+	0xa1, 0x00, //   Collection (Physical)
+	0x05, 0x09, //     Usage Page (Button)
+	0x19, 0x01, //     Usage Minimum (1)
+	0x29, 0x03, //     Usage Maximum (3)
+	0x15, 0x00, //     Logical Minimum (0)
+	0x25, 0x01, //     Logical Maximum (1)
+	0x95, 0x03, //     Report Count (3)
+	0x75, 0x01, //     Report Size (1)
+	0x81, 0x02, //     Input (Data, Variable, Absolute)
+	0x95, 0x01, //     Report Count (1)
+	0x75, 0x05, //     Report Size (5)
+	0x81, 0x03, //     Input (Constant, Variable, Absolute)
+	0x05, 0x01, //     Usage Page (Generic Desktop)
+	0x09, 0x30, //     Usage (X)
+	0x09, 0x31, //     Usage (Y)
+	0x15, 0x81, //     Logical Minimum (-127)
+	0x25, 0x7f, //     Logical Maximum (127)
+	0x75, 0x08, //     Report Size (8)
+	0x95, 0x02, //     Report Count (2)
+	0x81, 0x06, //     Input (Data, Variable, Relative)
+	0xc0, //   End Collection
+	0xc0, // End Collection
+}
+
+func TestDescriptorFIDO(t *testing.T) {
+	d, err := ParseReport(fidoDescriptor).Descriptor()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(d.Collections) != 1 {
+		t.Fatalf("got %d top-level collections, want 1", len(d.Collections))
+	}
+	app := d.Collections[0]
+	if app.Type != CollectionItemTypeApplication || app.UsagePage != 0xf1d0 || app.Usage != 0x01 {
+		t.Fatalf("application collection = %+v", app)
+	}
+	if len(app.Items) != 2 || len(d.Items) != 2 {
+		t.Fatalf("got %d collection items and %d descriptor items, want 2", len(app.Items), len(d.Items))
+	}
+
+	input, output := app.Items[0], app.Items[1]
+	if input.Tag != ItemTagMainInput || output.Tag != ItemTagMainOutput {
+		t.Fatalf("item tags = %v, %v", input.Tag, output.Tag)
+	}
+	if !slices.Equal(input.Usages, []ExtendedUsage{0xf1d0_0020}) ||
+		!slices.Equal(output.Usages, []ExtendedUsage{0xf1d0_0021}) {
+		t.Fatalf("usages = %#x, %#x; want [0xf1d00020], [0xf1d00021]", input.Usages, output.Usages)
+	}
+	if input.UsagePage != 0xf1d0 || input.LogicalMaximum != 0xff ||
+		input.ReportSize != 8 || input.ReportCount != 64 || input.ReportID != 0 {
+		t.Fatalf("input item = %+v", input)
+	}
+	if !input.IsVariable() || input.IsConstant() || input.Collection != app {
+		t.Fatalf("input flags/collection = %#x, %p", input.Flags, input.Collection)
+	}
+}
+
+func TestDescriptorNestedCollections(t *testing.T) {
+	d, err := ParseReport(mouseDescriptor).Descriptor()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	app := d.Collections[0]
+	if app.UsagePage != 0x01 || app.Usage != 0x02 || len(app.Items) != 0 {
+		t.Fatalf("application collection = %+v", app)
+	}
+	if len(app.Collections) != 1 {
+		t.Fatalf("got %d nested collections, want 1", len(app.Collections))
+	}
+	physical := app.Collections[0]
+	if physical.Type != CollectionItemTypePhysical || physical.Usage != 0x01 || physical.Parent != app {
+		t.Fatalf("physical collection = %+v", physical)
+	}
+	if len(physical.Items) != 3 {
+		t.Fatalf("got %d physical items, want 3", len(physical.Items))
+	}
+
+	padding := physical.Items[1]
+	if !padding.IsConstant() || len(padding.Usages) != 0 || padding.ReportSize != 5 {
+		t.Fatalf("padding item = %+v", padding)
+	}
+	axes := physical.Items[2]
+	if axes.UsagePage != 0x01 || !slices.Equal(axes.Usages, []ExtendedUsage{0x0001_0030, 0x0001_0031}) ||
+		!axes.IsRelative() || axes.ReportID != 1 || axes.ReportCount != 2 ||
+		axes.LogicalMinimum != -127 || axes.LogicalMaximum != 127 {
+		t.Fatalf("axes item = %+v", axes)
+	}
+}
+
+func TestDescriptorUnbalancedCollections(t *testing.T) {
+	if _, err := ParseReport(fidoDescriptor[:len(fidoDescriptor)-1]).Descriptor(); !errors.Is(err, ErrUnclosedCollection) {
+		t.Fatalf("error = %v, want ErrUnclosedCollection", err)
+	}
+	if _, err := ParseReport([]byte{0xc0}).Descriptor(); !errors.Is(err, ErrUnmatchedEndCollection) {
+		t.Fatalf("error = %v, want ErrUnmatchedEndCollection", err)
+	}
+}
+
+func TestDescriptorPushPop(t *testing.T) {
+	d, err := ParseReport([]byte{
+		0x05, 0x01, // Usage Page (Generic Desktop)
+		0x09, 0x05, // Usage (Game Pad)
+		0xa1, 0x01, // Collection (Application)
+		0x75, 0x08, //   Report Size (8)
+		0x95, 0x01, //   Report Count (1)
+		0xa4,       //   Push
+		0x05, 0x09, //   Usage Page (Button)
+		0x75, 0x01, //   Report Size (1)
+		0x95, 0x08, //   Report Count (8)
+		0x09, 0x01, //   Usage (Button 1)
+		0x81, 0x02, //   Input (Data, Variable, Absolute)
+		0xb4,       //   Pop
+		0x09, 0x30, //   Usage (X)
+		0x81, 0x02, //   Input (Data, Variable, Absolute)
+		0xc0, // End Collection
+	}).Descriptor()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	items := d.Collections[0].Items
+	if got := items[0]; got.UsagePage != 0x09 || got.ReportSize != 1 || got.ReportCount != 8 {
+		t.Fatalf("pushed item = %+v", got)
+	}
+	if got := items[1]; got.UsagePage != 0x01 || got.ReportSize != 8 || got.ReportCount != 1 ||
+		!slices.Equal(got.Usages, []ExtendedUsage{0x0001_0030}) {
+		t.Fatalf("popped item = %+v", got)
+	}
+}
+
+func TestDescriptorPopWithoutPush(t *testing.T) {
+	_, err := ParseReport([]byte{0x05, 0x01, 0xb4}).Descriptor()
+	if !errors.Is(err, ErrPopWithoutPush) {
+		t.Fatalf("error = %v, want ErrPopWithoutPush", err)
+	}
+}
+
+func TestDescriptorResetsLocalState(t *testing.T) {
+	d, err := ParseReport([]byte{
+		0x05, 0x01, // Usage Page (Generic Desktop)
+		0x09, 0x02, // Usage (Mouse)
+		0xa1, 0x01, // Collection (Application)
+		0x75, 0x08, //   Report Size (8)
+		0x95, 0x01, //   Report Count (1)
+		0x81, 0x03, //   Input (Constant, Variable, Absolute)
+		0x09, 0x30, //   Usage (X)
+		0x81, 0x02, //   Input (Data, Variable, Absolute)
+		0x81, 0x02, //   Input (Data, Variable, Absolute)
+		0xc0, // End Collection
+	}).Descriptor()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	items := d.Items
+	if len(items[0].Usages) != 0 {
+		t.Fatalf("collection usage leaked into the first item: %v", items[0].Usages)
+	}
+	if !slices.Equal(items[1].Usages, []ExtendedUsage{0x0001_0030}) {
+		t.Fatalf("second item usages = %#x, want [0x10030]", items[1].Usages)
+	}
+	if len(items[2].Usages) != 0 {
+		t.Fatalf("usage leaked into the third item: %v", items[2].Usages)
+	}
+}
+
+func TestDescriptorUsageRanges(t *testing.T) {
+	d, err := Parse([]byte{
+		0x05, 0x01, // Usage Page (Generic Desktop)
+		0x09, 0x06, // Usage (Keyboard)
+		0xa1, 0x01, // Collection (Application)
+		0x05, 0x07, //   Usage Page (Keyboard/Keypad)
+		0x19, 0xe0, //   Usage Minimum (Left Control)
+		0x29, 0xe7, //   Usage Maximum (Right GUI)
+		0x15, 0x00, //   Logical Minimum (0)
+		0x25, 0x01, //   Logical Maximum (1)
+		0x75, 0x01, //   Report Size (1)
+		0x95, 0x08, //   Report Count (8)
+		0x81, 0x02, //   Input (Data, Variable, Absolute)
+		0x29, 0x65, //   Usage Maximum (Keyboard Application)
+		0x19, 0x00, //   Usage Minimum (Reserved)
+		0x15, 0x00, //   Logical Minimum (0)
+		0x25, 0x65, //   Logical Maximum (101)
+		0x75, 0x08, //   Report Size (8)
+		0x95, 0x06, //   Report Count (6)
+		0x81, 0x00, //   Input (Data, Array, Absolute)
+		0x19, 0x01, //   Usage Minimum (1)
+		0x2a, 0xff, 0xff, //   Usage Maximum (65535)
+		0x95, 0x02, //   Report Count (2)
+		0x91, 0x02, //   Output (Data, Variable, Absolute)
+		0xc0, // End Collection
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	modifiers, keys, output := d.Items[0], d.Items[1], d.Items[2]
+	if len(modifiers.Usages) != 8 || modifiers.Usages[0] != 0x0007_00e0 || modifiers.Usages[7] != 0x0007_00e7 {
+		t.Fatalf("modifier usages = %#x", modifiers.Usages)
+	}
+	if len(keys.Usages) != 0x66 || keys.Usages[0] != 0x0007_0000 || keys.Usages[0x65] != 0x0007_0065 {
+		t.Fatalf("key usages = %d usages from %#x", len(keys.Usages), keys.Usages[:1])
+	}
+	if !slices.Equal(output.Usages, []ExtendedUsage{0x0007_0001, 0x0007_0002}) {
+		t.Fatalf("variable range was not limited to the report count: %d usages", len(output.Usages))
+	}
+}
+
+func TestDescriptorExtendedUsages(t *testing.T) {
+	d, err := Parse([]byte{
+		0x05, 0x01, // Usage Page (Generic Desktop)
+		0x0b, 0x01, 0x00, 0x0c, 0x00, // Usage (Consumer: Consumer Control)
+		0xa1, 0x01, // Collection (Application)
+		0x09, 0x30, //   Usage (X)
+		0x0b, 0xe9, 0x00, 0x0c, 0x00, //   Usage (Consumer: Volume Increment)
+		0x1b, 0x01, 0x00, 0x09, 0x00, //   Usage Minimum (Button: Button 1)
+		0x29, 0x02, //   Usage Maximum (Button 2)
+		0x75, 0x01, //   Report Size (1)
+		0x95, 0x04, //   Report Count (4)
+		0x81, 0x02, //   Input (Data, Variable, Absolute)
+		0xc0, // End Collection
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	app := d.Collections[0]
+	if app.UsagePage != 0x0c || app.Usage != 0x01 {
+		t.Fatalf("collection usage = %#x:%#x, want 0xc:0x1", app.UsagePage, app.Usage)
+	}
+	want := []ExtendedUsage{0x0001_0030, 0x000c_00e9, 0x0009_0001, 0x0009_0002}
+	if got := d.Items[0].Usages; !slices.Equal(got, want) {
+		t.Fatalf("usages = %#x, want %#x", got, want)
+	}
+}
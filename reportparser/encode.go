@@ -0,0 +1,108 @@
+package reportparser
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	ErrUnknownUsage    = errors.New("reportparser: usage is not part of the report")
+	ErrValueOutOfRange = errors.New("reportparser: value is outside the logical range")
+)
+
+// Encode packs values into a report of typ and id. The result starts with the
+// report ID byte, 0 for an unnumbered report, as Device.Write and
+// Device.SendFeatureReport expect.
+//
+// Every element of a variable field with an assigned usage receives its
+// value, which must lie within the logical range. Array fields select the
+// usages assigned a non-zero value, in declaration order. Unassigned elements
+// and constant fields are left zero.
+func (l *Layout) Encode(typ ReportType, id uint8, values map[ExtendedUsage]int32) ([]byte, error) {
+	report := l.Report(typ, id)
+	if report == nil {
+		return nil, fmt.Errorf("%w: %v %d", ErrUnknownReport, typ, id)
+	}
+
+	if report.BitLength < 0 || int64(report.BitLength) > maxReportBitLength(report.ID) {
+		return nil, fmt.Errorf("%w: %v report %d has %d bits", ErrReportTooLong, typ, id, report.BitLength)
+	}
+
+	b := make([]byte, 1+report.DataLength())
+	b[0] = id
+	data := b[1:]
+
+	assigned := make(map[ExtendedUsage]bool, len(values))
+	for _, field := range report.Fields {
+		item := field.Item
+		if item.IsConstant() || item.ReportSize == 0 {
+			continue
+		}
+		if !field.fits(len(data)) {
+			return nil, fmt.Errorf("%w: field at bit %d does not fit in %d data bytes", ErrShortReport, field.BitOffset, len(data))
+		}
+
+		if item.IsVariable() {
+			for i := range int(item.ReportCount) {
+				usage, ok := item.usage(i)
+				if !ok {
+					continue
+				}
+				v, ok := values[usage]
+				if !ok {
+					continue
+				}
+				if !item.inLogicalRange(v) {
+					return nil, fmt.Errorf("%w: usage %#08x value %d not in [%d, %d]",
+						ErrValueOutOfRange, uint32(usage), v, item.LogicalMinimum, item.LogicalMaximum)
+				}
+				insertBits(data, field.BitOffset+i*int(item.ReportSize), int(item.ReportSize), uint32(v))
+				assigned[usage] = true
+			}
+			continue
+		}
+
+		element := 0
+		for index := range item.Usages {
+			usage, _ := item.usage(index)
+			v, ok := values[usage]
+			if !ok {
+				continue
+			}
+			assigned[usage] = true
+			if v == 0 {
+				continue
+			}
+			if element == int(item.ReportCount) {
+				return nil, fmt.Errorf("%w: more than %d usages selected in an array field",
+					ErrValueOutOfRange, item.ReportCount)
+			}
+			selector := int64(item.LogicalMinimum) + int64(index)
+			if selector > int64(item.LogicalMaximum) {
+				return nil, fmt.Errorf("%w: usage %#08x has no array index", ErrValueOutOfRange, uint32(usage))
+			}
+			insertBits(data, field.BitOffset+element*int(item.ReportSize), int(item.ReportSize), uint32(selector))
+			element++
+		}
+	}
+
+	for usage := range values {
+		if !assigned[usage] {
+			return nil, fmt.Errorf("%w: %#08x", ErrUnknownUsage, uint32(usage))
+		}
+	}
+
+	return b, nil
+}
+
+// insertBits writes the low size bits of v, up to 32, starting at bit offset.
+func insertBits(data []byte, offset, size int, v uint32) {
+	for i := range min(size, 32) {
+		bit := offset + i
+		if v&(1<<i) != 0 {
+			data[bit/8] |= 1 << (bit % 8)
+		} else {
+			data[bit/8] &^= 1 << (bit % 8)
+		}
+	}
+}
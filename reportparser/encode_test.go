@@ -0,0 +1,206 @@
+package reportparser
+
+import (
+	"errors"
+	"math"
+	"slices"
+	"testing"
+)
+
+var ledDescriptor = []byte{
+	0x05, 0x01, // Usage Page (Generic Desktop)
+	0x09, 0x06, // Usage (Keyboard)
+	0xa1, 0x01, // Collection (Application)
+	0x85, 0x02, //   Report ID (2)
+	0x05, 0x08, //   Usage Page (LED)
+	0x09, 0x01, //   Usage (Num Lock)
+	0x09, 0x02, //   Usage (Caps Lock)
+	0x09, 0x03, //   Usage (Scroll Lock)
+	0x15, 0x00, //   Logical Minimum (0)
+	0x25, 0x01, //   Logical Maximum (1)
+	0x75, 0x01, //   Report Size (1)
+	0x95, 0x03, //   Report Count (3)
+	0x91, 0x02, //   Output (Data, Variable, Absolute)
+	0x95, 0x05, //   Report Count (5)
+	0x91, 0x03, //   Output (Constant, Variable, Absolute)
+	0x05, 0xff, //   Usage Page (0xFF)
+	0x09, 0x10, //   Usage (0x10)
+	0x15, 0x9c, //   Logical Minimum (-100)
+	0x25, 0x64, //   Logical Maximum (100)
+	0x75, 0x08, //   Report Size (8)
+	0x95, 0x01, //   Report Count (1)
+	0xb1, 0x02, //   Feature (Data, Variable, Absolute)
+	0x09, 0x20, //   Usage (0x20)
+	0x09, 0x21, //   Usage (0x21)
+	0x09, 0x22, //   Usage (0x22)
+	0x15, 0x01, //   Logical Minimum (1)
+	0x25, 0x03, //   Logical Maximum (3)
+	0x95, 0x02, //   Report Count (2)
+	0xb1, 0x00, //   Feature (Data, Array, Absolute)
+	0xc0, // End Collection
+}
+
+func TestEncodeOutputReport(t *testing.T) {
+	d, err := Parse(ledDescriptor)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	l, err := d.Layout()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	b, err := l.Encode(ReportTypeOutput, 2, map[ExtendedUsage]int32{
+		NewExtendedUsage(0x08, 0x01): 1,
+		NewExtendedUsage(0x08, 0x02): 0,
+		NewExtendedUsage(0x08, 0x03): 1,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []byte{0x02, 0b101}; !slices.Equal(b, want) {
+		t.Fatalf("Encode() = %#v, want %#v", b, want)
+	}
+}
+
+func TestEncodeFeatureReportRoundTrip(t *testing.T) {
+	d, err := Parse(ledDescriptor)
+	if err != nil {
+		t.Fatal(err)
+	}
+	l, err := d.Layout()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	b, err := l.Encode(ReportTypeFeature, 2, map[ExtendedUsage]int32{
+		NewExtendedUsage(0xff, 0x10): -42,
+		NewExtendedUsage(0xff, 0x20): 0,
+		NewExtendedUsage(0xff, 0x22): 1,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []byte{0x02, 0xd6, 0x03, 0x00}; !slices.Equal(b, want) {
+		t.Fatalf("Encode() = %#v, want %#v", b, want)
+	}
+
+	values, err := l.Decode(ReportTypeFeature, b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, ok := values.Get(NewExtendedUsage(0xff, 0x10)); !ok || got != -42 {
+		t.Fatalf("decoded value = %d, %v; want -42", got, ok)
+	}
+	if want := []ExtendedUsage{NewExtendedUsage(0xff, 0x22)}; !slices.Equal(values.Active, want) {
+		t.Fatalf("decoded active usages = %v, want %v", values.Active, want)
+	}
+}
+
+func TestEncodeErrors(t *testing.T) {
+	d, err := Parse(ledDescriptor)
+	if err != nil {
+		t.Fatal(err)
+	}
+	l, err := d.Layout()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		typ     ReportType
+		id      uint8
+		values  map[ExtendedUsage]int32
+		wantErr error
+	}{
+		{
+			name:    "unknown report",
+			typ:     ReportTypeInput,
+			id:      2,
+			wantErr: ErrUnknownReport,
+		},
+		{
+			name:    "above logical maximum",
+			typ:     ReportTypeOutput,
+			id:      2,
+			values:  map[ExtendedUsage]int32{NewExtendedUsage(0x08, 0x01): 2},
+			wantErr: ErrValueOutOfRange,
+		},
+		{
+			name:    "below logical minimum",
+			typ:     ReportTypeFeature,
+			id:      2,
+			values:  map[ExtendedUsage]int32{NewExtendedUsage(0xff, 0x10): -101},
+			wantErr: ErrValueOutOfRange,
+		},
+		{
+			name: "too many array usages",
+			typ:  ReportTypeFeature,
+			id:   2,
+			values: map[ExtendedUsage]int32{
+				NewExtendedUsage(0xff, 0x20): 1,
+				NewExtendedUsage(0xff, 0x21): 1,
+				NewExtendedUsage(0xff, 0x22): 1,
+			},
+			wantErr: ErrValueOutOfRange,
+		},
+		{
+			name:    "unknown usage",
+			typ:     ReportTypeOutput,
+			id:      2,
+			values:  map[ExtendedUsage]int32{NewExtendedUsage(0x08, 0x04): 1},
+			wantErr: ErrUnknownUsage,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if _, err := l.Encode(test.typ, test.id, test.values); !errors.Is(err, test.wantErr) {
+				t.Fatalf("Encode() error = %v, want %v", err, test.wantErr)
+			}
+		})
+	}
+}
+
+func TestEncodeInvalidLayout(t *testing.T) {
+	item := &MainItem{Tag: ItemTagMainOutput, ReportSize: 8, ReportCount: 2, Flags: uint32(OutputFlagVariable)}
+	tests := []struct {
+		name    string
+		report  *Report
+		wantErr error
+	}{
+		{
+			name:    "negative length",
+			report:  &Report{Type: ReportTypeOutput, BitLength: -16},
+			wantErr: ErrReportTooLong,
+		},
+		{
+			name:    "overflowing length",
+			report:  &Report{Type: ReportTypeOutput, BitLength: math.MaxInt},
+			wantErr: ErrReportTooLong,
+		},
+		{
+			name:    "longer than the maximum",
+			report:  &Report{Type: ReportTypeOutput, ID: 1, BitLength: maxReportLength * 8},
+			wantErr: ErrReportTooLong,
+		},
+		{
+			name: "field past the end",
+			report: &Report{Type: ReportTypeOutput, BitLength: 8, Fields: []*Field{
+				{Item: item, BitLength: 16},
+			}},
+			wantErr: ErrShortReport,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			l := &Layout{Reports: []*Report{test.report}}
+			if _, err := l.Encode(test.report.Type, test.report.ID, nil); !errors.Is(err, test.wantErr) {
+				t.Fatalf("Encode() error = %v, want %v", err, test.wantErr)
+			}
+		})
+	}
+}
@@ -20,7 +20,7 @@ func (p UsagePage) Value() uint16 {
 	return uint16(p)
 }
 
-type LogicalMinimum uint32
+type LogicalMinimum int32
 
 func (p LogicalMinimum) Name() string {
 	return "Logical Minimum"
@@ -30,11 +30,11 @@ func (p LogicalMinimum) Tag() ItemTag {
 	return ItemTagGlobalLogicalMinimum
 }
 
-func (p LogicalMinimum) Value() uint32 {
-	return uint32(p)
+func (p LogicalMinimum) Value() int32 {
+	return int32(p)
 }
 
-type LogicalMaximum uint32
+type LogicalMaximum int32
 
 func (p LogicalMaximum) Name() string {
 	return "Logical Maximum"
@@ -44,11 +44,67 @@ func (p LogicalMaximum) Tag() ItemTag {
 	return ItemTagGlobalLogicalMaximum
 }
 
-func (p LogicalMaximum) Value() uint32 {
-	return uint32(p)
+func (p LogicalMaximum) Value() int32 {
+	return int32(p)
 }
 
-type Usage uint16
+type PhysicalMinimum int32
+
+func (p PhysicalMinimum) Name() string {
+	return "Physical Minimum"
+}
+
+func (p PhysicalMinimum) Tag() ItemTag {
+	return ItemTagGlobalPhysicalMinimum
+}
+
+func (p PhysicalMinimum) Value() int32 {
+	return int32(p)
+}
+
+type PhysicalMaximum int32
+
+func (p PhysicalMaximum) Name() string {
+	return "Physical Maximum"
+}
+
+func (p PhysicalMaximum) Tag() ItemTag {
+	return ItemTagGlobalPhysicalMaximum
+}
+
+func (p PhysicalMaximum) Value() int32 {
+	return int32(p)
+}
+
+type UnitExponent int32
+
+func (e UnitExponent) Name() string {
+	return "Unit Exponent"
+}
+
+func (e UnitExponent) Tag() ItemTag {
+	return ItemTagGlobalUnitExponent
+}
+
+func (e UnitExponent) Value() int32 {
+	return int32(e)
+}
+
+func (u Unit) Name() string {
+	return "Unit"
+}
+
+func (u Unit) Tag() ItemTag {
+	return ItemTagGlobalUnit
+}
+
+func (u Unit) Value() Unit {
+	return u
+}
+
+// Usage is a usage ID, or an extended usage when it was encoded in four bytes
+// with the usage page in the upper 16 bits.
+type Usage uint32
 
 func (u Usage) Name() string {
 	return "Usage"
@@ -58,8 +114,135 @@ func (u Usage) Tag() ItemTag {
 	return ItemTagLocalUsage
 }
 
-func (u Usage) Value() uint16 {
-	return uint16(u)
+func (u Usage) Value() uint32 {
+	return uint32(u)
+}
+
+type UsageMinimum uint32
+
+func (u UsageMinimum) Name() string {
+	return "Usage Minimum"
+}
+
+func (u UsageMinimum) Tag() ItemTag {
+	return ItemTagLocalUsageMinimum
+}
+
+func (u UsageMinimum) Value() uint32 {
+	return uint32(u)
+}
+
+type UsageMaximum uint32
+
+func (u UsageMaximum) Name() string {
+	return "Usage Maximum"
+}
+
+func (u UsageMaximum) Tag() ItemTag {
+	return ItemTagLocalUsageMaximum
+}
+
+func (u UsageMaximum) Value() uint32 {
+	return uint32(u)
+}
+
+type DesignatorIndex uint32
+
+func (d DesignatorIndex) Name() string {
+	return "Designator Index"
+}
+
+func (d DesignatorIndex) Tag() ItemTag {
+	return ItemTagLocalDesignatorIndex
+}
+
+func (d DesignatorIndex) Value() uint32 {
+	return uint32(d)
+}
+
+type DesignatorMinimum uint32
+
+func (d DesignatorMinimum) Name() string {
+	return "Designator Minimum"
+}
+
+func (d DesignatorMinimum) Tag() ItemTag {
+	return ItemTagLocalDesignatorMinimum
+}
+
+func (d DesignatorMinimum) Value() uint32 {
+	return uint32(d)
+}
+
+type DesignatorMaximum uint32
+
+func (d DesignatorMaximum) Name() string {
+	return "Designator Maximum"
+}
+
+func (d DesignatorMaximum) Tag() ItemTag {
+	return ItemTagLocalDesignatorMaximum
+}
+
+func (d DesignatorMaximum) Value() uint32 {
+	return uint32(d)
+}
+
+type StringIndex uint32
+
+func (s StringIndex) Name() string {
+	return "String Index"
+}
+
+func (s StringIndex) Tag() ItemTag {
+	return ItemTagLocalStringIndex
+}
+
+func (s StringIndex) Value() uint32 {
+	return uint32(s)
+}
+
+type StringMinimum uint32
+
+func (s StringMinimum) Name() string {
+	return "String Minimum"
+}
+
+func (s StringMinimum) Tag() ItemTag {
+	return ItemTagLocalStringMinimum
+}
+
+func (s StringMinimum) Value() uint32 {
+	return uint32(s)
+}
+
+type StringMaximum uint32
+
+func (s StringMaximum) Name() string {
+	return "String Maximum"
+}
+
+func (s StringMaximum) Tag() ItemTag {
+	return ItemTagLocalStringMaximum
+}
+
+func (s StringMaximum) Value() uint32 {
+	return uint32(s)
+}
+
+// Delimiter opens (1) or closes (0) a set of alternative usages.
+type Delimiter uint32
+
+func (d Delimiter) Name() string {
+	return "Delimiter"
+}
+
+func (d Delimiter) Tag() ItemTag {
+	return ItemTagLocalDelimiter
+}
+
+func (d Delimiter) Value() uint32 {
+	return uint32(d)
 }
 
 type Input InputFlags
@@ -132,7 +315,7 @@ func (e EndCollection) Value() struct{} {
 	return struct{}{}
 }
 
-type ReportSize byte
+type ReportSize uint32
 
 func (r ReportSize) Name() string {
 	return "Report Size"
@@ -160,7 +343,7 @@ func (r ReportID) Value() ReportID {
 	return r
 }
 
-type ReportCount byte
+type ReportCount uint32
 
 func (r ReportCount) Name() string {
 	return "Report Count"
@@ -173,3 +356,50 @@ func (r ReportCount) Tag() ItemTag {
 func (r ReportCount) Value() ReportCount {
 	return r
 }
+
+type Push struct{}
+
+func (p Push) Name() string {
+	return "Push"
+}
+
+func (p Push) Tag() ItemTag {
+	return ItemTagGlobalPush
+}
+
+func (p Push) Value() struct{} {
+	return struct{}{}
+}
+
+type Pop struct{}
+
+func (p Pop) Name() string {
+	return "Pop"
+}
+
+func (p Pop) Tag() ItemTag {
+	return ItemTagGlobalPop
+}
+
+func (p Pop) Value() struct{} {
+	return struct{}{}
+}
+
+// LongItem is a long item. HID 1.11 defines no long item tags, so the data is
+// kept verbatim.
+type LongItem struct {
+	LongTag byte
+	Data    []byte
+}
+
+func (l LongItem) Name() string {
+	return "Long Item"
+}
+
+func (l LongItem) Tag() ItemTag {
+	return ItemTagLong
+}
+
+func (l LongItem) Value() []byte {
+	return l.Data
+}
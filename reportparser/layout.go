@@ -0,0 +1,172 @@
+package reportparser
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	ErrUnknownReport     = errors.New("reportparser: unknown report")
+	ErrShortReport       = errors.New("reportparser: report is shorter than its layout")
+	ErrInvalidReportSize = errors.New("reportparser: Report Size is not between 1 and 32")
+	ErrReportTooLong     = errors.New("reportparser: report is longer than the maximum report length")
+)
+
+// maxReportLength is the length of the longest report, including the report
+// ID byte, that the Linux hidraw driver (HID_MAX_BUFFER_SIZE) passes on.
+const maxReportLength = 16384
+
+// Layout places the main items of a descriptor into reports.
+type Layout struct {
+	// Reports holds the reports in order of their first main item.
+	Reports []*Report
+}
+
+// Report is the layout of one report type and report ID.
+type Report struct {
+	Type ReportType
+	ID   uint8
+	// BitLength is the number of data bits, excluding the report ID byte.
+	BitLength int
+	Fields    []*Field
+}
+
+// Field is a main item placed in a report. Element i of the field occupies
+// Item.ReportSize bits starting at BitOffset + i*Item.ReportSize.
+type Field struct {
+	Item *MainItem
+	// BitOffset is relative to the first data byte, after the report ID.
+	BitOffset int
+	BitLength int
+}
+
+// Layout computes the bit offset and length of every field. It fails if a
+// field has a Report Size of 0 or more than 32 bits, or if a report is longer
+// than maxReportLength.
+func (d *Descriptor) Layout() (*Layout, error) {
+	l := &Layout{}
+
+	for _, item := range d.Items {
+		typ, ok := reportTypeOf(item.Tag)
+		if !ok {
+			continue
+		}
+
+		if item.ReportSize == 0 || item.ReportSize > 32 {
+			return nil, fmt.Errorf("%w: %v item has Report Size %d", ErrInvalidReportSize, typ, item.ReportSize)
+		}
+
+		report := l.Report(typ, item.ReportID)
+		if report == nil {
+			report = &Report{Type: typ, ID: item.ReportID}
+			l.Reports = append(l.Reports, report)
+		}
+
+		// With at most 32 bits per element, neither the product nor the sum
+		// can overflow an int64.
+		length := int64(item.ReportSize) * int64(item.ReportCount)
+		if int64(report.BitLength)+length > maxReportBitLength(report.ID) {
+			return nil, fmt.Errorf("%w: %v report %d", ErrReportTooLong, typ, report.ID)
+		}
+
+		field := &Field{
+			Item:      item,
+			BitOffset: report.BitLength,
+			BitLength: int(length),
+		}
+		report.Fields = append(report.Fields, field)
+		report.BitLength += field.BitLength
+	}
+
+	return l, nil
+}
+
+// maxReportBitLength returns the number of data bits that fit in a report
+// of maxReportLength bytes.
+func maxReportBitLength(id uint8) int64 {
+	if id != 0 {
+		return (maxReportLength - 1) * 8
+	}
+	return maxReportLength * 8
+}
+
+func reportTypeOf(tag ItemTag) (ReportType, bool) {
+	switch tag {
+	case ItemTagMainInput:
+		return ReportTypeInput, true
+	case ItemTagMainOutput:
+		return ReportTypeOutput, true
+	case ItemTagMainFeature:
+		return ReportTypeFeature, true
+	default:
+		return 0, false
+	}
+}
+
+// Report returns the report with the given type and ID, or nil.
+func (l *Layout) Report(typ ReportType, id uint8) *Report {
+	for _, report := range l.Reports {
+		if report.Type == typ && report.ID == id {
+			return report
+		}
+	}
+	return nil
+}
+
+// Numbered reports whether the reports are prefixed with a report ID.
+func (l *Layout) Numbered() bool {
+	for _, report := range l.Reports {
+		if report.ID != 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// MaxByteLength returns the length of the largest report of typ, including
+// the report ID byte of numbered reports.
+func (l *Layout) MaxByteLength(typ ReportType) int {
+	n := 0
+	for _, report := range l.Reports {
+		if report.Type == typ {
+			n = max(n, report.ByteLength())
+		}
+	}
+	return n
+}
+
+// Find returns the report of typ that b holds and checks that b is long
+// enough for it. As Device.Read returns them, numbered reports start with the
+// report ID and unnumbered reports start with data.
+func (l *Layout) Find(typ ReportType, b []byte) (*Report, error) {
+	var id uint8
+	if l.Numbered() {
+		if len(b) == 0 {
+			return nil, ErrShortReport
+		}
+		id = b[0]
+	}
+
+	report := l.Report(typ, id)
+	if report == nil {
+		return nil, fmt.Errorf("%w: %v %d", ErrUnknownReport, typ, id)
+	}
+	if len(b) < report.ByteLength() {
+		return nil, fmt.Errorf("%w: got %d bytes, want %d", ErrShortReport, len(b), report.ByteLength())
+	}
+	return report, nil
+}
+
+// DataLength returns the number of data bytes, excluding the report ID.
+func (r *Report) DataLength() int {
+	return (r.BitLength + 7) / 8
+}
+
+// ByteLength returns the length of the report including the report ID byte
+// of a numbered report.
+func (r *Report) ByteLength() int {
+	if r.ID != 0 {
+		return r.DataLength() + 1
+	}
+	return r.DataLength()
+}
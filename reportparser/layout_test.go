@@ -0,0 +1,166 @@
+package reportparser
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestLayoutFIDO(t *testing.T) {
+	d, err := Parse(fidoDescriptor)
+	if err != nil {
+		t.Fatal(err)
+	}
+	l, err := d.Layout()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(l.Reports) != 2 || l.Numbered() {
+		t.Fatalf("layout = %d reports, numbered %v; want 2 unnumbered", len(l.Reports), l.Numbered())
+	}
+	for _, typ := range []ReportType{ReportTypeInput, ReportTypeOutput} {
+		report := l.Report(typ, 0)
+		if report == nil {
+			t.Fatalf("missing %v report", typ)
+		}
+		if report.BitLength != 512 || report.ByteLength() != 64 || len(report.Fields) != 1 {
+			t.Fatalf("%v report = %+v", typ, report)
+		}
+		if got := l.MaxByteLength(typ); got != 64 {
+			t.Fatalf("MaxByteLength(%v) = %d, want 64", typ, got)
+		}
+	}
+	if l.Report(ReportTypeFeature, 0) != nil || l.MaxByteLength(ReportTypeFeature) != 0 {
+		t.Fatal("layout has an unexpected feature report")
+	}
+}
+
+func TestLayoutNumbered(t *testing.T) {
+	d, err := Parse(mouseDescriptor)
+	if err != nil {
+		t.Fatal(err)
+	}
+	l, err := d.Layout()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	report := l.Report(ReportTypeInput, 1)
+	if report == nil || !l.Numbered() {
+		t.Fatal("missing numbered input report 1")
+	}
+	if report.BitLength != 24 || report.DataLength() != 3 || report.ByteLength() != 4 {
+		t.Fatalf("report lengths = %d bits, %d data bytes, %d bytes", report.BitLength, report.DataLength(), report.ByteLength())
+	}
+
+	wantFields := []struct{ offset, length int }{{0, 3}, {3, 5}, {8, 16}}
+	if len(report.Fields) != len(wantFields) {
+		t.Fatalf("got %d fields, want %d", len(report.Fields), len(wantFields))
+	}
+	for i, want := range wantFields {
+		if got := report.Fields[i]; got.BitOffset != want.offset || got.BitLength != want.length {
+			t.Fatalf("field %d = offset %d length %d, want offset %d length %d",
+				i, got.BitOffset, got.BitLength, want.offset, want.length)
+		}
+	}
+
+	if found, err := l.Find(ReportTypeInput, []byte{1, 0, 0, 0}); err != nil || found != report {
+		t.Fatalf("Find() = %p, %v; want report 1", found, err)
+	}
+	if _, err := l.Find(ReportTypeInput, []byte{1, 0, 0}); !errors.Is(err, ErrShortReport) {
+		t.Fatalf("Find() error = %v, want ErrShortReport", err)
+	}
+	if _, err := l.Find(ReportTypeInput, []byte{2, 0, 0, 0}); !errors.Is(err, ErrUnknownReport) {
+		t.Fatalf("Find() error = %v, want ErrUnknownReport", err)
+	}
+}
+
+func TestLayoutErrors(t *testing.T) {
+	tests := []struct {
+		name       string
+		descriptor []byte
+		wantErr    error
+	}{
+		{
+			name: "zero Report Size",
+			descriptor: []byte{
+				0x06, 0x00, 0xff, 0x09, 0x01, 0xa1, 0x01,
+				0x09, 0x02, 0x75, 0x00, 0x95, 0x01, 0x81, 0x02,
+				0xc0,
+			},
+			wantErr: ErrInvalidReportSize,
+		},
+		{
+			name: "Report Size above 32",
+			descriptor: []byte{
+				0x06, 0x00, 0xff, 0x09, 0x01, 0xa1, 0x01,
+				0x09, 0x02, 0x75, 0x21, 0x95, 0x01, 0x81, 0x02,
+				0xc0,
+			},
+			wantErr: ErrInvalidReportSize,
+		},
+		{
+			name: "overflowing report",
+			descriptor: []byte{
+				0x05, 0x01, 0x09, 0x02, 0xa1, 0x01,
+				0x09, 0x30, 0x15, 0x00, 0x25, 0x01,
+				0x77, 0xff, 0xff, 0xff, 0xff, // Report Size (4294967295)
+				0x97, 0xff, 0xff, 0xff, 0xff, // Report Count (4294967295)
+				0x81, 0x02,
+				0xc0,
+			},
+			wantErr: ErrInvalidReportSize,
+		},
+		{
+			name: "report longer than the maximum",
+			descriptor: []byte{
+				0x06, 0x00, 0xff, 0x09, 0x01, 0xa1, 0x01,
+				0x09, 0x02, 0x75, 0x08, 0x96, 0x01, 0x40, // Report Count (16385)
+				0x81, 0x02,
+				0xc0,
+			},
+			wantErr: ErrReportTooLong,
+		},
+		{
+			name: "report longer than the maximum in total",
+			descriptor: []byte{
+				0x06, 0x00, 0xff, 0x09, 0x01, 0xa1, 0x01,
+				0x85, 0x01, 0x09, 0x02, 0x75, 0x20, 0x96, 0x00, 0x08, // Report Count (2048)
+				0x81, 0x02, 0x81, 0x02,
+				0xc0,
+			},
+			wantErr: ErrReportTooLong,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			d, err := Parse(test.descriptor)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if _, err := d.Layout(); !errors.Is(err, test.wantErr) {
+				t.Fatalf("Layout() error = %v, want %v", err, test.wantErr)
+			}
+		})
+	}
+}
+
+func TestLayoutMaximumReportLength(t *testing.T) {
+	d, err := Parse([]byte{
+		0x06, 0x00, 0xff, 0x09, 0x01, 0xa1, 0x01,
+		0x85, 0x01, 0x09, 0x02, 0x75, 0x08, 0x96, 0xff, 0x3f, // Report Count (16383)
+		0x81, 0x02,
+		0xc0,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	l, err := d.Layout()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := l.MaxByteLength(ReportTypeInput); got != maxReportLength {
+		t.Fatalf("MaxByteLength() = %d, want %d", got, maxReportLength)
+	}
+}
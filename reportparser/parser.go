@@ -2,87 +2,237 @@ package reportparser
 
 import (
 	"encoding/binary"
+	"errors"
+	"fmt"
 )
 
+var (
+	ErrTruncatedItem   = errors.New("reportparser: truncated item")
+	ErrReservedItem    = errors.New("reportparser: reserved item")
+	ErrInvalidReportID = errors.New("reportparser: Report ID is greater than 255")
+)
+
+// longItemPrefix introduces a long item. The data size and the long item tag
+// follow in the next two bytes.
+const longItemPrefix = 0xfe
+
 type Items []any
 
+// ParseError annotates a descriptor error with the byte offset of the item
+// that caused it.
+type ParseError struct {
+	Offset int
+	Err    error
+}
+
+func (e *ParseError) Error() string {
+	return fmt.Sprintf("%v at offset %d", e.Err, e.Offset)
+}
+
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
+
+// ParseReport decodes the items of a report descriptor. Reserved items and
+// Report IDs greater than 255 are skipped and decoding stops at a truncated
+// item; use ParseItems or Parse to find out whether the descriptor was
+// well-formed.
 func ParseReport(b []byte) Items {
 	r := make(Items, 0)
 
 	for i := 0; i < len(b); {
-		size := ItemSize(b[i] & 0b00000011)
-		tag := ItemTag((b[i] & 0b11111100) >> 2)
-
-		i++
-
-		switch tag {
-		case ItemTagMainInput:
-			r = append(r, Input(parseUintValue(size, b[i:])))
-		case ItemTagMainOutput:
-			r = append(r, Output(parseUintValue(size, b[i:])))
-		case ItemTagMainFeature:
-			r = append(r, Feature(parseUintValue(size, b[i:])))
-		case ItemTagMainCollection:
-			r = append(r, Collection(b[i]))
-		case ItemTagMainEndCollection:
-			r = append(r, EndCollection{})
-		case ItemTagGlobalUsagePage:
-			r = append(r, UsagePage(parseUintValue(size, b[i:])))
-		case ItemTagGlobalLogicalMinimum:
-			r = append(r, LogicalMinimum(parseUintValue(size, b[i:])))
-		case ItemTagGlobalLogicalMaximum:
-			r = append(r, LogicalMaximum(parseUintValue(size, b[i:])))
-		case ItemTagGlobalPhysicalMinimum:
-		case ItemTagGlobalPhysicalMaximum:
-		case ItemTagGlobalUnitExponent:
-		case ItemTagGlobalUnit:
-		case ItemTagGlobalReportSize:
-			r = append(r, ReportSize(b[i]))
-		case ItemTagGlobalReportID:
-			r = append(r, ReportID(b[i]))
-		case ItemTagGlobalReportCount:
-			r = append(r, ReportCount(b[i]))
-		case ItemTagGlobalPush:
-		case ItemTagGlobalPop:
-		case ItemTagLocalUsage:
-			r = append(r, Usage(parseUintValue(size, b[i:])))
-		case ItemTagLocalUsageMinimum:
-		case ItemTagLocalUsageMaximum:
-		case ItemTagLocalDesignatorIndex:
-		case ItemTagLocalDesignatorMinimum:
-		case ItemTagLocalDesignatorMaximum:
-		case ItemTagLocalStringIndex:
-		case ItemTagLocalStringMinimum:
-		case ItemTagLocalStringMaximum:
-		case ItemTagLocalDelimiter:
+		item, next, err := parseItem(b, i)
+		if errors.Is(err, ErrTruncatedItem) {
+			break
 		}
-
-		// skip read bytes
-		switch size {
-		case ItemSize0:
-			i += 0
-		case ItemSize8:
-			i += 1
-		case ItemSize16:
-			i += 2
-		case ItemSize32:
-			i += 4
+		if err == nil {
+			r = append(r, item)
 		}
+		i = next
 	}
 
 	return r
 }
 
-func parseUintValue(size ItemSize, buf []byte) uint32 {
-	switch size {
-	case ItemSize0:
-		return 0
-	case ItemSize8:
+// ParseItems decodes the items of a report descriptor. It fails with a
+// *ParseError at the first truncated or reserved item or invalid Report ID.
+func ParseItems(b []byte) (Items, error) {
+	r, _, err := parseItems(b)
+	return r, err
+}
+
+// Parse decodes a report descriptor and resolves it into a collection tree.
+// Errors are reported as *ParseError.
+func Parse(b []byte) (*Descriptor, error) {
+	items, offsets, err := parseItems(b)
+	if err != nil {
+		return nil, err
+	}
+
+	d, index, err := buildDescriptor(items)
+	if err != nil {
+		offset := len(b)
+		if index < len(offsets) {
+			offset = offsets[index]
+		}
+		return nil, &ParseError{Offset: offset, Err: err}
+	}
+	return d, nil
+}
+
+// parseItems returns the decoded items together with their byte offsets.
+func parseItems(b []byte) (Items, []int, error) {
+	r := make(Items, 0)
+	offsets := make([]int, 0)
+
+	for i := 0; i < len(b); {
+		item, next, err := parseItem(b, i)
+		if err != nil {
+			return r, offsets, &ParseError{Offset: i, Err: err}
+		}
+		r = append(r, item)
+		offsets = append(offsets, i)
+		i = next
+	}
+
+	return r, offsets, nil
+}
+
+// parseItem decodes the item at offset i and returns the offset of the next
+// item. A reserved item or invalid Report ID is reported with a valid next
+// offset so that callers may skip it.
+func parseItem(b []byte, i int) (any, int, error) {
+	if b[i] == longItemPrefix {
+		if len(b)-i < 3 {
+			return nil, len(b), ErrTruncatedItem
+		}
+		size := int(b[i+1])
+		start := i + 3
+		if len(b)-start < size {
+			return nil, len(b), ErrTruncatedItem
+		}
+		return LongItem{
+			LongTag: b[i+2],
+			Data:    append([]byte(nil), b[start:start+size]...),
+		}, start + size, nil
+	}
+
+	size := ItemSize(b[i] & 0b00000011)
+	tag := ItemTag((b[i] & 0b11111100) >> 2)
+
+	start := i + 1
+	n := size.Len()
+	if len(b)-start < n {
+		return nil, len(b), ErrTruncatedItem
+	}
+	data := b[start : start+n]
+	next := start + n
+
+	switch tag {
+	case ItemTagMainInput:
+		return Input(parseUintValue(data)), next, nil
+	case ItemTagMainOutput:
+		return Output(parseUintValue(data)), next, nil
+	case ItemTagMainFeature:
+		return Feature(parseUintValue(data)), next, nil
+	case ItemTagMainCollection:
+		return Collection(parseUintValue(data)), next, nil
+	case ItemTagMainEndCollection:
+		return EndCollection{}, next, nil
+	case ItemTagGlobalUsagePage:
+		return UsagePage(parseUintValue(data)), next, nil
+	case ItemTagGlobalLogicalMinimum:
+		return LogicalMinimum(parseIntValue(data)), next, nil
+	case ItemTagGlobalLogicalMaximum:
+		return LogicalMaximum(parseIntValue(data)), next, nil
+	case ItemTagGlobalPhysicalMinimum:
+		return PhysicalMinimum(parseIntValue(data)), next, nil
+	case ItemTagGlobalPhysicalMaximum:
+		return PhysicalMaximum(parseIntValue(data)), next, nil
+	case ItemTagGlobalUnitExponent:
+		return UnitExponent(parseUnitExponent(data)), next, nil
+	case ItemTagGlobalUnit:
+		return Unit(parseUintValue(data)), next, nil
+	case ItemTagGlobalReportSize:
+		return ReportSize(parseUintValue(data)), next, nil
+	case ItemTagGlobalReportID:
+		// A wider Report ID would collide with the ID of its low byte.
+		id := parseUintValue(data)
+		if id > 0xff {
+			return nil, next, ErrInvalidReportID
+		}
+		return ReportID(id), next, nil
+	case ItemTagGlobalReportCount:
+		return ReportCount(parseUintValue(data)), next, nil
+	case ItemTagGlobalPush:
+		return Push{}, next, nil
+	case ItemTagGlobalPop:
+		return Pop{}, next, nil
+	case ItemTagLocalUsage:
+		return Usage(parseUintValue(data)), next, nil
+	case ItemTagLocalUsageMinimum:
+		return UsageMinimum(parseUintValue(data)), next, nil
+	case ItemTagLocalUsageMaximum:
+		return UsageMaximum(parseUintValue(data)), next, nil
+	case ItemTagLocalDesignatorIndex:
+		return DesignatorIndex(parseUintValue(data)), next, nil
+	case ItemTagLocalDesignatorMinimum:
+		return DesignatorMinimum(parseUintValue(data)), next, nil
+	case ItemTagLocalDesignatorMaximum:
+		return DesignatorMaximum(parseUintValue(data)), next, nil
+	case ItemTagLocalStringIndex:
+		return StringIndex(parseUintValue(data)), next, nil
+	case ItemTagLocalStringMinimum:
+		return StringMinimum(parseUintValue(data)), next, nil
+	case ItemTagLocalStringMaximum:
+		return StringMaximum(parseUintValue(data)), next, nil
+	case ItemTagLocalDelimiter:
+		return Delimiter(parseUintValue(data)), next, nil
+	default:
+		return nil, next, ErrReservedItem
+	}
+}
+
+func parseUintValue(buf []byte) uint32 {
+	switch len(buf) {
+	case 1:
 		return uint32(buf[0])
-	case ItemSize16:
+	case 2:
 		return uint32(binary.LittleEndian.Uint16(buf))
-	case ItemSize32:
+	case 4:
 		return binary.LittleEndian.Uint32(buf)
 	}
 	return 0
 }
+
+// parseIntValue sign-extends the item data according to its size.
+func parseIntValue(buf []byte) int32 {
+	switch len(buf) {
+	case 1:
+		return int32(int8(buf[0]))
+	case 2:
+		return int32(int16(binary.LittleEndian.Uint16(buf)))
+	case 4:
+		return int32(binary.LittleEndian.Uint32(buf))
+	}
+	return 0
+}
+
+// parseUnitExponent decodes a Unit Exponent. HID 1.11 encodes it as a 4-bit
+// two's complement nibble, but many devices store a full signed value, so
+// values outside the nibble are taken as they are.
+func parseUnitExponent(buf []byte) int32 {
+	v := parseIntValue(buf)
+	if v&^0xf == 0 {
+		return signExtendNibble(uint32(v))
+	}
+	return v
+}
+
+func signExtendNibble(v uint32) int32 {
+	v &= 0xf
+	if v >= 8 {
+		return int32(v) - 16
+	}
+	return int32(v)
+}
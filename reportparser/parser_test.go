@@ -0,0 +1,186 @@
+package reportparser
+
+import (
+	"errors"
+	"reflect"
+	"slices"
+	"testing"
+)
+
+func TestParseReportValues(t *testing.T) {
+	items := ParseReport([]byte{
+		0x06, 0x00, 0xff, // Usage Page (Vendor Defined 0xFF00)
+		0x75, 0x08, // Report Size (8)
+		0x96, 0x00, 0x01, // Report Count (256)
+		0x85, 0x02, // Report ID (2)
+		0xa0, // Collection (Physical), no data
+	})
+
+	want := Items{UsagePage(0xff00), ReportSize(8), ReportCount(256), ReportID(2), Collection(0)}
+	if !reflect.DeepEqual(items, want) {
+		t.Fatalf("ParseReport() = %#v, want %#v", items, want)
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		name       string
+		descriptor []byte
+		wantErr    error
+		wantOffset int
+	}{
+		{
+			name:       "truncated 8-bit item",
+			descriptor: []byte{0x05, 0x01, 0x09},
+			wantErr:    ErrTruncatedItem,
+			wantOffset: 2,
+		},
+		{
+			name:       "truncated 16-bit item",
+			descriptor: []byte{0x05, 0x01, 0x26, 0xff},
+			wantErr:    ErrTruncatedItem,
+			wantOffset: 2,
+		},
+		{
+			name:       "truncated 32-bit item",
+			descriptor: []byte{0x07, 0x01, 0x00, 0x00},
+			wantErr:    ErrTruncatedItem,
+			wantOffset: 0,
+		},
+		{
+			name:       "truncated long item header",
+			descriptor: []byte{0x05, 0x01, 0xfe, 0x02},
+			wantErr:    ErrTruncatedItem,
+			wantOffset: 2,
+		},
+		{
+			name:       "truncated long item data",
+			descriptor: []byte{0xfe, 0x04, 0xf0, 0x01, 0x02},
+			wantErr:    ErrTruncatedItem,
+			wantOffset: 0,
+		},
+		{
+			name:       "reserved item type",
+			descriptor: []byte{0x05, 0x01, 0x0d, 0x00},
+			wantErr:    ErrReservedItem,
+			wantOffset: 2,
+		},
+		{
+			name:       "reserved main tag",
+			descriptor: []byte{0x05, 0x01, 0xf0},
+			wantErr:    ErrReservedItem,
+			wantOffset: 2,
+		},
+		{
+			name:       "16-bit Report ID",
+			descriptor: []byte{0x05, 0x01, 0x86, 0x01, 0x01},
+			wantErr:    ErrInvalidReportID,
+			wantOffset: 2,
+		},
+		{
+			name:       "unclosed collection",
+			descriptor: []byte{0x05, 0x01, 0xa1, 0x01},
+			wantErr:    ErrUnclosedCollection,
+			wantOffset: 4,
+		},
+		{
+			name:       "pop without push",
+			descriptor: []byte{0x05, 0x01, 0xb4},
+			wantErr:    ErrPopWithoutPush,
+			wantOffset: 2,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := Parse(test.descriptor)
+			if !errors.Is(err, test.wantErr) {
+				t.Fatalf("Parse() error = %v, want %v", err, test.wantErr)
+			}
+			var parseErr *ParseError
+			if !errors.As(err, &parseErr) {
+				t.Fatalf("Parse() error = %T, want *ParseError", err)
+			}
+			if parseErr.Offset != test.wantOffset {
+				t.Fatalf("Parse() error offset = %d, want %d", parseErr.Offset, test.wantOffset)
+			}
+		})
+	}
+}
+
+func TestParseLongItem(t *testing.T) {
+	items, err := ParseItems([]byte{
+		0xfe, 0x02, 0xf0, 0xaa, 0xbb, // Long Item (0xF0)
+		0x05, 0x01, // Usage Page (Generic Desktop)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(items) != 2 {
+		t.Fatalf("got %d items, want 2: %#v", len(items), items)
+	}
+	long, ok := items[0].(LongItem)
+	if !ok || long.LongTag != 0xf0 || !slices.Equal(long.Data, []byte{0xaa, 0xbb}) {
+		t.Fatalf("long item = %#v", items[0])
+	}
+	if items[1] != UsagePage(1) {
+		t.Fatalf("item after long item = %#v, want Usage Page (1)", items[1])
+	}
+}
+
+func TestParseReportSkipsReservedItems(t *testing.T) {
+	items := ParseReport([]byte{0x0d, 0x00, 0x05, 0x01, 0x09})
+	if !reflect.DeepEqual(items, Items{UsagePage(1)}) {
+		t.Fatalf("ParseReport() = %#v, want only Usage Page (1)", items)
+	}
+}
+
+func TestParseReportSkipsInvalidReportID(t *testing.T) {
+	items := ParseReport([]byte{0x86, 0x01, 0x01, 0x85, 0x02})
+	if !reflect.DeepEqual(items, Items{ReportID(2)}) {
+		t.Fatalf("ParseReport() = %#v, want only Report ID (2)", items)
+	}
+	items = ParseReport([]byte{0x86, 0xff, 0x00})
+	if !reflect.DeepEqual(items, Items{ReportID(0xff)}) {
+		t.Fatalf("ParseReport() = %#v, want Report ID (255)", items)
+	}
+}
+
+func FuzzParse(f *testing.F) {
+	f.Add(fidoDescriptor)
+	f.Add(mouseDescriptor)
+	f.Add([]byte{0xfe, 0xff, 0x00})
+	f.Add([]byte{0x27, 0xff, 0xff, 0xff})
+	f.Add(overflowDescriptor)
+	f.Fuzz(func(t *testing.T, b []byte) {
+		lenient := ParseReport(b)
+		items, itemsErr := ParseItems(b)
+		_, _ = Parse(b)
+		_ = Validate(b)
+
+		if itemsErr == nil && !reflect.DeepEqual(items, lenient) {
+			t.Fatalf("ParseItems() = %#v, ParseReport() = %#v", items, lenient)
+		}
+		if itemsErr == nil {
+			encoded, err := items.Bytes()
+			if err != nil {
+				t.Fatalf("Bytes() error = %v", err)
+			}
+			if again, err := ParseItems(encoded); err != nil || !reflect.DeepEqual(again, items) {
+				t.Fatalf("ParseItems(Bytes()) = %#v, %v; want %#v", again, err, items)
+			}
+
+			text, err := items.MarshalText()
+			if err != nil {
+				t.Fatalf("MarshalText() error = %v", err)
+			}
+			if again, err := ParseText(text); err != nil || !reflect.DeepEqual(again, items) {
+				t.Fatalf("ParseText(MarshalText()) = %#v, %v; want %#v", again, err, items)
+			}
+		}
+		var parseErr *ParseError
+		if itemsErr != nil && (!errors.As(itemsErr, &parseErr) || parseErr.Offset < 0 || parseErr.Offset >= len(b)) {
+			t.Fatalf("ParseItems() error = %v, want *ParseError within the descriptor", itemsErr)
+		}
+	})
+}
@@ -0,0 +1,509 @@
+package reportparser
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/telesma-app/hid/reportparser/usages"
+)
+
+var ErrInvalidText = errors.New("reportparser: invalid descriptor text")
+
+// textIndent indents the items of a collection.
+const textIndent = "    "
+
+// MarshalText formats the items one per line, in the style of
+// "hidrd-convert -o spec": the item name followed by its value in
+// parentheses, indented by collection. Usage pages and usages are named from
+// the HID Usage Tables and main item flags list the flags that are set.
+// ParseText and UnmarshalText read the output back into the same items, so
+// encoders that use encoding.TextMarshaler, such as encoding/json, write items
+// as one string.
+//
+//	Usage Page (Generic Desktop)
+//	Usage (Mouse)
+//	Collection (Application)
+//	    Report Size (1)
+//	    Input (Variable)
+//	End Collection
+func (items Items) MarshalText() ([]byte, error) {
+	var b bytes.Buffer
+	var state textState
+	depth := 0
+
+	for i, item := range items {
+		if _, ok := item.(EndCollection); ok && depth > 0 {
+			depth--
+		}
+
+		name, value, comment, err := state.format(item)
+		if err != nil {
+			return nil, fmt.Errorf("item %d: %w", i, err)
+		}
+
+		b.WriteString(strings.Repeat(textIndent, depth))
+		b.WriteString(name)
+		if value != "" {
+			b.WriteString(" (" + value + ")")
+		}
+		if comment != "" {
+			b.WriteString(" ; " + comment)
+		}
+		b.WriteByte('\n')
+
+		if _, ok := item.(Collection); ok {
+			depth++
+		}
+	}
+
+	return b.Bytes(), nil
+}
+
+// UnmarshalText replaces the items with the items that ParseText parses from
+// b.
+func (items *Items) UnmarshalText(b []byte) error {
+	parsed, err := ParseText(b)
+	if err != nil {
+		return err
+	}
+	*items = parsed
+
+	return nil
+}
+
+// ParseText parses items in the format of Items.MarshalText. Lines may end
+// with a comma and a comment introduced by a semicolon, and values may be
+// written as numbers instead of names.
+func ParseText(b []byte) (Items, error) {
+	r := make(Items, 0)
+	var state textState
+
+	scanner := bufio.NewScanner(bytes.NewReader(b))
+	for line := 1; scanner.Scan(); line++ {
+		name, value, ok := splitTextLine(scanner.Text())
+		if !ok {
+			continue
+		}
+
+		item, err := state.parse(name, value)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		r = append(r, item)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
+
+// textState tracks the Usage Page in effect, which usage names depend on.
+type textState struct {
+	usagePage uint16
+	stack     []uint16
+}
+
+func (s *textState) update(item any) {
+	switch e := item.(type) {
+	case UsagePage:
+		s.usagePage = uint16(e)
+	case Push:
+		s.stack = append(s.stack, s.usagePage)
+	case Pop:
+		if len(s.stack) > 0 {
+			s.usagePage = s.stack[len(s.stack)-1]
+			s.stack = s.stack[:len(s.stack)-1]
+		}
+	}
+}
+
+// format returns the name, value and comment of item.
+func (s *textState) format(item any) (string, string, string, error) {
+	defer s.update(item)
+
+	switch e := item.(type) {
+	case UsagePage:
+		page := usages.Page(e)
+		if name, ok := page.Name(); ok {
+			if p, ok := usages.LookupPage(name); ok && p == page {
+				return e.Name(), name, "", nil
+			}
+		}
+		return e.Name(), fmt.Sprintf("0x%04X", uint16(e)), "", nil
+	case Usage:
+		return e.Name(), s.formatUsage(uint32(e)), "", nil
+	case UsageMinimum:
+		return e.Name(), s.formatUsage(uint32(e)), "", nil
+	case UsageMaximum:
+		return e.Name(), s.formatUsage(uint32(e)), "", nil
+	case Collection:
+		if name, ok := constName(e.Value(), "CollectionItemType"); ok {
+			return e.Name(), name, "", nil
+		}
+		return e.Name(), fmt.Sprintf("0x%02X", uint8(e)), "", nil
+	case Input:
+		return e.Name(), formatFlags(e.Value(), "InputFlag"), "", nil
+	case Output:
+		return e.Name(), formatFlags(e.Value(), "OutputFlag"), "", nil
+	case Feature:
+		return e.Name(), formatFlags(e.Value(), "FeatureFlag"), "", nil
+	case Unit:
+		return e.Name(), fmt.Sprintf("0x%X", uint32(e)), formatUnit(e), nil
+	case LongItem:
+		if len(e.Data) == 0 {
+			return e.Name(), fmt.Sprintf("0x%02X", e.LongTag), "", nil
+		}
+		return e.Name(), fmt.Sprintf("0x%02X %x", e.LongTag, e.Data), "", nil
+	case EndCollection:
+		return e.Name(), "", "", nil
+	case Push:
+		return e.Name(), "", "", nil
+	case Pop:
+		return e.Name(), "", "", nil
+	case LogicalMinimum:
+		return e.Name(), formatInt(int32(e)), "", nil
+	case LogicalMaximum:
+		return e.Name(), formatInt(int32(e)), "", nil
+	case PhysicalMinimum:
+		return e.Name(), formatInt(int32(e)), "", nil
+	case PhysicalMaximum:
+		return e.Name(), formatInt(int32(e)), "", nil
+	case UnitExponent:
+		return e.Name(), formatInt(int32(e)), "", nil
+	case ReportSize:
+		return e.Name(), formatUint(uint32(e)), "", nil
+	case ReportID:
+		return e.Name(), formatUint(uint32(e)), "", nil
+	case ReportCount:
+		return e.Name(), formatUint(uint32(e)), "", nil
+	case DesignatorIndex:
+		return e.Name(), formatUint(uint32(e)), "", nil
+	case DesignatorMinimum:
+		return e.Name(), formatUint(uint32(e)), "", nil
+	case DesignatorMaximum:
+		return e.Name(), formatUint(uint32(e)), "", nil
+	case StringIndex:
+		return e.Name(), formatUint(uint32(e)), "", nil
+	case StringMinimum:
+		return e.Name(), formatUint(uint32(e)), "", nil
+	case StringMaximum:
+		return e.Name(), formatUint(uint32(e)), "", nil
+	case Delimiter:
+		return e.Name(), formatUint(uint32(e)), "", nil
+	default:
+		return "", "", "", fmt.Errorf("%w: %T", ErrUnencodableItem, item)
+	}
+}
+
+func formatInt(v int32) string {
+	return strconv.FormatInt(int64(v), 10)
+}
+
+func formatUint(v uint32) string {
+	return strconv.FormatUint(uint64(v), 10)
+}
+
+// constName returns the stringer name of v without prefix, or false when v
+// has no name.
+func constName(v fmt.Stringer, prefix string) (string, bool) {
+	name := v.String()
+	if strings.Contains(name, "(") {
+		return "", false
+	}
+	return strings.TrimPrefix(name, prefix), true
+}
+
+// formatUsage names a usage of the current usage page. Extended usages and
+// usages without a name are written in hex.
+func (s *textState) formatUsage(v uint32) string {
+	if v > math.MaxUint16 {
+		return fmt.Sprintf("0x%08X", v)
+	}
+	usage := usages.New(usages.Page(s.usagePage), uint16(v))
+	if name, ok := usage.Name(); ok {
+		if u, ok := usages.Lookup(usage.Page(), name); ok && u == usage {
+			return name
+		}
+	}
+	return fmt.Sprintf("0x%02X", v)
+}
+
+// formatFlags lists the names of the set flags. Bits without a name are
+// written in hex.
+func formatFlags[F interface {
+	~uint32
+	fmt.Stringer
+}](flags F, prefix string) string {
+	var names []string
+	var unnamed uint32
+	for v := uint32(flags); v != 0; v &= v - 1 {
+		bit := v & -v
+		name, ok := constName(F(bit), prefix)
+		if !ok {
+			unnamed |= bit
+			continue
+		}
+		names = append(names, name)
+	}
+	if unnamed != 0 {
+		names = append(names, fmt.Sprintf("0x%X", unnamed))
+	}
+	return strings.Join(names, ", ")
+}
+
+// formatUnit describes a unit as its system and the exponents of its base
+// units, such as "SILinear: Length^1 Time^-2", or as its system alone, such
+// as "None", without exponents.
+func formatUnit(u Unit) string {
+	system, ok := constName(u.System(), "UnitSystem")
+	if !ok {
+		system = fmt.Sprintf("0x%X", uint8(u.System()))
+	}
+	parts := []string{system + ":"}
+	for _, base := range []struct {
+		name     string
+		exponent int8
+	}{
+		{"Length", u.Length()},
+		{"Mass", u.Mass()},
+		{"Time", u.Time()},
+		{"Temperature", u.Temperature()},
+		{"Current", u.Current()},
+		{"LuminousIntensity", u.LuminousIntensity()},
+	} {
+		if base.exponent != 0 {
+			parts = append(parts, fmt.Sprintf("%s^%d", base.name, base.exponent))
+		}
+	}
+	if len(parts) == 1 {
+		return system
+	}
+	return strings.Join(parts, " ")
+}
+
+// splitTextLine returns the item name and value of a line, or false for a
+// blank or comment line. Usage names may contain parentheses and semicolons,
+// so the value ends at the first closing parenthesis that is followed only by
+// a comma or a comment.
+func splitTextLine(line string) (string, string, bool) {
+	line = strings.TrimSpace(line)
+	if line == "" || strings.HasPrefix(line, ";") {
+		return "", "", false
+	}
+
+	open := strings.Index(line, "(")
+	comment := strings.Index(line, ";")
+	if open < 0 || (comment >= 0 && comment < open) {
+		if comment >= 0 {
+			line = line[:comment]
+		}
+		return strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(line), ",")), "", true
+	}
+
+	name := strings.TrimSpace(line[:open])
+	rest := line[open+1:]
+	for i := range len(rest) {
+		if rest[i] != ')' {
+			continue
+		}
+		tail := strings.TrimSpace(rest[i+1:])
+		tail = strings.TrimSpace(strings.TrimPrefix(tail, ","))
+		if tail == "" || strings.HasPrefix(tail, ";") {
+			return name, strings.TrimSpace(rest[:i]), true
+		}
+	}
+	// An unclosed value is reported by parse.
+	return name, "(" + rest, true
+}
+
+// parse returns the item named name with value.
+func (s *textState) parse(name, value string) (any, error) {
+	item, err := s.parseItem(name, value)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s (%s): %w", ErrInvalidText, name, value, err)
+	}
+	s.update(item)
+	return item, nil
+}
+
+func (s *textState) parseItem(name, value string) (any, error) {
+	switch name {
+	case UsagePage(0).Name():
+		if page, ok := usages.LookupPage(value); ok {
+			return UsagePage(page), nil
+		}
+		v, err := parseTextUint(value, 16)
+		return UsagePage(v), err
+	case Usage(0).Name():
+		v, err := s.parseUsage(value)
+		return Usage(v), err
+	case UsageMinimum(0).Name():
+		v, err := s.parseUsage(value)
+		return UsageMinimum(v), err
+	case UsageMaximum(0).Name():
+		v, err := s.parseUsage(value)
+		return UsageMaximum(v), err
+	case LogicalMinimum(0).Name():
+		v, err := parseTextInt(value)
+		return LogicalMinimum(v), err
+	case LogicalMaximum(0).Name():
+		v, err := parseTextInt(value)
+		return LogicalMaximum(v), err
+	case PhysicalMinimum(0).Name():
+		v, err := parseTextInt(value)
+		return PhysicalMinimum(v), err
+	case PhysicalMaximum(0).Name():
+		v, err := parseTextInt(value)
+		return PhysicalMaximum(v), err
+	case UnitExponent(0).Name():
+		v, err := parseTextInt(value)
+		return UnitExponent(v), err
+	case Unit(0).Name():
+		v, err := parseTextUint(value, 32)
+		return Unit(v), err
+	case ReportSize(0).Name():
+		v, err := parseTextUint(value, 32)
+		return ReportSize(v), err
+	case ReportID(0).Name():
+		v, err := parseTextUint(value, 8)
+		return ReportID(v), err
+	case ReportCount(0).Name():
+		v, err := parseTextUint(value, 32)
+		return ReportCount(v), err
+	case DesignatorIndex(0).Name():
+		v, err := parseTextUint(value, 32)
+		return DesignatorIndex(v), err
+	case DesignatorMinimum(0).Name():
+		v, err := parseTextUint(value, 32)
+		return DesignatorMinimum(v), err
+	case DesignatorMaximum(0).Name():
+		v, err := parseTextUint(value, 32)
+		return DesignatorMaximum(v), err
+	case StringIndex(0).Name():
+		v, err := parseTextUint(value, 32)
+		return StringIndex(v), err
+	case StringMinimum(0).Name():
+		v, err := parseTextUint(value, 32)
+		return StringMinimum(v), err
+	case StringMaximum(0).Name():
+		v, err := parseTextUint(value, 32)
+		return StringMaximum(v), err
+	case Delimiter(0).Name():
+		v, err := parseTextUint(value, 32)
+		return Delimiter(v), err
+	case Input(0).Name():
+		v, err := parseFlags[InputFlags](value, "InputFlag")
+		return Input(v), err
+	case Output(0).Name():
+		v, err := parseFlags[OutputFlags](value, "OutputFlag")
+		return Output(v), err
+	case Feature(0).Name():
+		v, err := parseFlags[FeatureFlags](value, "FeatureFlag")
+		return Feature(v), err
+	case Collection(0).Name():
+		for typ := CollectionItemTypePhysical; typ <= CollectionItemTypeUsageModifier; typ++ {
+			if name, _ := constName(typ, "CollectionItemType"); name == value {
+				return Collection(typ), nil
+			}
+		}
+		v, err := parseTextUint(value, 8)
+		return Collection(v), err
+	case EndCollection{}.Name():
+		return EndCollection{}, checkNoTextValue(value)
+	case Push{}.Name():
+		return Push{}, checkNoTextValue(value)
+	case Pop{}.Name():
+		return Pop{}, checkNoTextValue(value)
+	case LongItem{}.Name():
+		tagText, dataText, _ := strings.Cut(value, " ")
+		tag, err := parseTextUint(tagText, 8)
+		if err != nil {
+			return nil, err
+		}
+		data, err := hex.DecodeString(strings.TrimSpace(dataText))
+		if err != nil {
+			return nil, err
+		}
+		if len(data) == 0 {
+			data = nil
+		}
+		return LongItem{LongTag: byte(tag), Data: data}, nil
+	default:
+		return nil, errors.New("unknown item")
+	}
+}
+
+// parseUsage accepts a usage name of the current usage page or a number.
+func (s *textState) parseUsage(value string) (uint32, error) {
+	if usage, ok := usages.Lookup(usages.Page(s.usagePage), value); ok {
+		return uint32(usage.ID()), nil
+	}
+	v, err := parseTextUint(value, 32)
+	return uint32(v), err
+}
+
+// parseFlags accepts a comma-separated list of flag names and numbers. The
+// names of cleared flags, such as Data or Absolute, are accepted and ignored.
+func parseFlags[F interface {
+	~uint32
+	fmt.Stringer
+}](value, prefix string) (F, error) {
+	var flags F
+	if value == "" {
+		return flags, nil
+	}
+	for _, part := range strings.Split(value, ",") {
+		part = strings.TrimSpace(part)
+		if isClearedFlagName(part) {
+			continue
+		}
+		found := false
+		for i := range 32 {
+			bit := F(1) << i
+			if name, ok := constName(bit, prefix); ok && name == part {
+				flags |= bit
+				found = true
+				break
+			}
+		}
+		if found {
+			continue
+		}
+		v, err := parseTextUint(part, 32)
+		if err != nil {
+			return 0, err
+		}
+		flags |= F(v)
+	}
+	return flags, nil
+}
+
+func isClearedFlagName(name string) bool {
+	switch name {
+	case "Data", "Array", "Absolute", "NoWrap", "Linear", "PreferredState", "NoNullPosition", "NonVolatile", "BitField":
+		return true
+	}
+	return false
+}
+
+func checkNoTextValue(value string) error {
+	if value != "" {
+		return errors.New("unexpected value")
+	}
+	return nil
+}
+
+func parseTextUint(value string, bitSize int) (uint64, error) {
+	return strconv.ParseUint(value, 0, bitSize)
+}
+
+func parseTextInt(value string) (int64, error) {
+	return strconv.ParseInt(value, 0, 32)
+}
@@ -0,0 +1,182 @@
+package reportparser
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestMarshalTextMouse(t *testing.T) {
+	got, err := ParseReport(mouseDescriptor).MarshalText()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := `Usage Page (Generic Desktop)
+Usage (Mouse)
+Collection (Application)
+    Report ID (1)
+    Usage (Pointer)
+    Collection (Physical)
+        Usage Page (Button)
+        Usage Minimum (Button 1)
+        Usage Maximum (Button 3)
+        Logical Minimum (0)
+        Logical Maximum (1)
+        Report Count (3)
+        Report Size (1)
+        Input (Variable)
+        Report Count (1)
+        Report Size (5)
+        Input (Constant, Variable)
+        Usage Page (Generic Desktop)
+        Usage (X)
+        Usage (Y)
+        Logical Minimum (-127)
+        Logical Maximum (127)
+        Report Size (8)
+        Report Count (2)
+        Input (Variable, Relative)
+    End Collection
+End Collection
+`
+	if string(got) != want {
+		t.Fatalf("MarshalText() =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestTextRoundTrip(t *testing.T) {
+	tests := map[string]Items{
+		"fido":  ParseReport(fidoDescriptor),
+		"mouse": ParseReport(mouseDescriptor),
+		"led":   ParseReport(ledDescriptor),
+		"special items": {
+			UsagePage(0xff42),
+			Usage(0x01),
+			UsagePage(0x07),
+			Usage(0xb7), // Keypad )
+			Usage(0x33), // Keyboard ; and :
+			Usage(0x000c_00e9),
+			Push{},
+			UsagePage(0x1234),
+			Pop{},
+			Usage(0xe0),
+			Collection(0x80),
+			Unit(0xf0d121),
+			UnitExponent(-3),
+			StringIndex(4),
+			Delimiter(1),
+			Input(InputFlagNullState | 1<<8),
+			Feature(FeatureFlagVolatile | FeatureFlagBufferedBytes),
+			EndCollection{},
+			LongItem{LongTag: 0xf0, Data: []byte{0xde, 0xad}},
+			LongItem{LongTag: 0xf1},
+		},
+	}
+
+	for name, items := range tests {
+		t.Run(name, func(t *testing.T) {
+			text, err := items.MarshalText()
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := ParseText(text)
+			if err != nil {
+				t.Fatalf("ParseText() error = %v for\n%s", err, text)
+			}
+			if !reflect.DeepEqual(got, items) {
+				t.Fatalf("ParseText() = %#v, want %#v, text\n%s", got, items, text)
+			}
+		})
+	}
+}
+
+func TestMarshalTextUnit(t *testing.T) {
+	tests := []struct {
+		unit Unit
+		want string
+	}{
+		{0, "Unit (0x0) ; None\n"},
+		{0x01, "Unit (0x1) ; SILinear\n"},
+		{0xe011, "Unit (0xE011) ; SILinear: Length^1 Time^-2\n"},
+	}
+	for _, test := range tests {
+		got, err := Items{test.unit}.MarshalText()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(got) != test.want {
+			t.Errorf("MarshalText(Unit(%#x)) = %q, want %q", uint32(test.unit), got, test.want)
+		}
+	}
+}
+
+func TestItemsJSONRoundTrip(t *testing.T) {
+	items := ParseReport(mouseDescriptor)
+	b, err := json.Marshal(items)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got Items
+	if err := json.Unmarshal(b, &got); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, items) {
+		t.Fatalf("json round trip = %#v, want %#v", got, items)
+	}
+}
+
+func TestParseTextHidrdStyle(t *testing.T) {
+	items, err := ParseText([]byte(`
+; Keyboard LEDs
+Usage Page (LED),                   ; LEDs (08h)
+Usage Minimum (0x01),
+Usage Maximum (Kana),
+Report Size (1),
+Report Count (5),
+Output (Data, Variable, Absolute),
+Report Count (1),
+Report Size (3),
+Output (Constant)
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := Items{
+		UsagePage(0x08),
+		UsageMinimum(0x01),
+		UsageMaximum(0x05),
+		ReportSize(1),
+		ReportCount(5),
+		Output(OutputFlagVariable),
+		ReportCount(1),
+		ReportSize(3),
+		Output(OutputFlagConstant),
+	}
+	if !reflect.DeepEqual(items, want) {
+		t.Fatalf("ParseText() = %#v, want %#v", items, want)
+	}
+}
+
+func TestParseTextErrors(t *testing.T) {
+	for _, text := range []string{
+		"Usage Page (Generic Desktop)\nUsage (Not A Usage)",
+		"Report Size (1)\nReport ID (256)",
+		"Frobnicate (1)",
+		"Input (Variable, Sideways)",
+		"End Collection (1)",
+		"Logical Minimum (1",
+	} {
+		_, err := ParseText([]byte(text))
+		if !errors.Is(err, ErrInvalidText) {
+			t.Errorf("ParseText(%q) error = %v, want %v", text, err, ErrInvalidText)
+		}
+		if want := strings.Count(text, "\n") + 1; err != nil && !strings.HasPrefix(err.Error(), fmt.Sprintf("line %d:", want)) {
+			t.Errorf("ParseText(%q) error = %v, want line %d", text, err, want)
+		}
+	}
+}
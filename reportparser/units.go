@@ -0,0 +1,65 @@
+package reportparser
+
+import "math"
+
+// Unit is the unit of the values of the following main items. The low nibble
+// selects the unit system; each further nibble holds the 4-bit two's
+// complement exponent of one base unit.
+type Unit uint32
+
+func (u Unit) System() UnitSystem {
+	return UnitSystem(u & 0xf)
+}
+
+// Length is the exponent of centimeters (SI Linear), radians (SI Rotation),
+// inches (English Linear) or degrees (English Rotation).
+func (u Unit) Length() int8 {
+	return u.exponent(1)
+}
+
+// Mass is the exponent of grams (SI) or slugs (English).
+func (u Unit) Mass() int8 {
+	return u.exponent(2)
+}
+
+// Time is the exponent of seconds.
+func (u Unit) Time() int8 {
+	return u.exponent(3)
+}
+
+// Temperature is the exponent of kelvin (SI) or degrees Fahrenheit (English).
+func (u Unit) Temperature() int8 {
+	return u.exponent(4)
+}
+
+// Current is the exponent of amperes.
+func (u Unit) Current() int8 {
+	return u.exponent(5)
+}
+
+// LuminousIntensity is the exponent of candelas.
+func (u Unit) LuminousIntensity() int8 {
+	return u.exponent(6)
+}
+
+func (u Unit) exponent(nibble int) int8 {
+	return int8(signExtendNibble(uint32(u) >> (4 * nibble)))
+}
+
+// Physical converts a logical value into physical units scaled by the unit
+// exponent. As HID 1.11 specifies, a Physical Minimum and Maximum of zero
+// default to the logical extents.
+func (m *MainItem) Physical(v int32) float64 {
+	physicalMinimum, physicalMaximum := m.PhysicalMinimum, m.PhysicalMaximum
+	if physicalMinimum == 0 && physicalMaximum == 0 {
+		physicalMinimum, physicalMaximum = m.LogicalMinimum, m.LogicalMaximum
+	}
+
+	x := float64(v)
+	if m.LogicalMaximum != m.LogicalMinimum {
+		scale := (float64(physicalMaximum) - float64(physicalMinimum)) /
+			(float64(m.LogicalMaximum) - float64(m.LogicalMinimum))
+		x = float64(physicalMinimum) + (x-float64(m.LogicalMinimum))*scale
+	}
+	return x * math.Pow10(int(m.UnitExponent))
+}
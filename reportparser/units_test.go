@@ -0,0 +1,122 @@
+package reportparser
+
+import (
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestParseSignedValues(t *testing.T) {
+	items, err := ParseItems([]byte{
+		0x15, 0x81, // Logical Minimum (-127)
+		0x26, 0xff, 0x7f, // Logical Maximum (32767)
+		0x37, 0x00, 0x00, 0x00, 0x80, // Physical Minimum (-2147483648)
+		0x46, 0x18, 0xfc, // Physical Maximum (-1000)
+		0x55, 0x0e, // Unit Exponent (-2), nibble encoding
+		0x55, 0xfd, // Unit Exponent (-3), signed byte encoding
+		0x67, 0x11, 0xf0, 0x00, 0x00, // Unit (SI Linear: cm, s^-1)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := Items{
+		LogicalMinimum(-127),
+		LogicalMaximum(32767),
+		PhysicalMinimum(math.MinInt32),
+		PhysicalMaximum(-1000),
+		UnitExponent(-2),
+		UnitExponent(-3),
+		Unit(0xf011),
+	}
+	if !reflect.DeepEqual(items, want) {
+		t.Fatalf("ParseItems() = %#v, want %#v", items, want)
+	}
+}
+
+func TestUnit(t *testing.T) {
+	// SI Linear acceleration: cm * s^-2.
+	unit := Unit(0xe011)
+	if unit.System() != UnitSystemSILinear {
+		t.Fatalf("System() = %v, want %v", unit.System(), UnitSystemSILinear)
+	}
+	if unit.Length() != 1 || unit.Mass() != 0 || unit.Time() != -2 ||
+		unit.Temperature() != 0 || unit.Current() != 0 || unit.LuminousIntensity() != 0 {
+		t.Fatalf("exponents = %d %d %d %d %d %d", unit.Length(), unit.Mass(), unit.Time(),
+			unit.Temperature(), unit.Current(), unit.LuminousIntensity())
+	}
+
+	// SI Linear temperature with luminous intensity: K^-8 * cd^7.
+	unit = Unit(0x0708_0001)
+	if unit.Temperature() != -8 || unit.LuminousIntensity() != 7 {
+		t.Fatalf("Temperature() = %d, LuminousIntensity() = %d, want -8, 7", unit.Temperature(), unit.LuminousIntensity())
+	}
+}
+
+func TestMainItemPhysical(t *testing.T) {
+	d, err := Parse([]byte{
+		0x05, 0x20, // Usage Page (Sensors)
+		0x09, 0x73, // Usage (Motion: Accelerometer 3D)
+		0xa1, 0x01, // Collection (Application)
+		0x16, 0x01, 0x80, //   Logical Minimum (-32767)
+		0x26, 0xff, 0x7f, //   Logical Maximum (32767)
+		0x36, 0x01, 0x80, //   Physical Minimum (-32767)
+		0x46, 0xff, 0x7f, //   Physical Maximum (32767)
+		0x55, 0x0e, //   Unit Exponent (-2)
+		0x66, 0x11, 0xe0, //   Unit (cm * s^-2)
+		0x75, 0x10, //   Report Size (16)
+		0x95, 0x01, //   Report Count (1)
+		0x81, 0x02, //   Input (Data, Variable, Absolute)
+		0x15, 0x00, //   Logical Minimum (0)
+		0x25, 0x64, //   Logical Maximum (100)
+		0x35, 0x00, //   Physical Minimum (0)
+		0x46, 0xe8, 0x03, //   Physical Maximum (1000)
+		0x55, 0x00, //   Unit Exponent (0)
+		0x81, 0x02, //   Input (Data, Variable, Absolute)
+		0x35, 0x00, //   Physical Minimum (0)
+		0x45, 0x00, //   Physical Maximum (0)
+		0x81, 0x02, //   Input (Data, Variable, Absolute)
+		0x45, 0xff, //   Physical Maximum (255, encoded as -1)
+		0x81, 0x02, //   Input (Data, Variable, Absolute)
+		0xc0, // End Collection
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	acceleration := d.Items[0]
+	if acceleration.Unit.System() != UnitSystemSILinear || acceleration.Unit.Time() != -2 || acceleration.UnitExponent != -2 {
+		t.Fatalf("acceleration unit = %#x, exponent %d", uint32(acceleration.Unit), acceleration.UnitExponent)
+	}
+	if got := acceleration.Physical(-981); math.Abs(got-(-9.81)) > 1e-9 {
+		t.Fatalf("Physical(-981) = %v, want -9.81", got)
+	}
+	if got := d.Items[1].Physical(25); got != 250 {
+		t.Fatalf("Physical(25) = %v, want 250", got)
+	}
+	if got := d.Items[2].Physical(25); got != 25 {
+		t.Fatalf("Physical(25) without physical extents = %v, want 25", got)
+	}
+	if got := d.Items[3].Physical(100); math.Abs(got-255) > 1e-9 {
+		t.Fatalf("Physical(100) with a Physical Maximum of FF = %v, want 255", got)
+	}
+}
+
+func TestUnsignedMaximum(t *testing.T) {
+	tests := []struct {
+		minimum, maximum, want int32
+	}{
+		{0, -1, 255},
+		{0, -128, 128},
+		{0, -129, 65407},
+		{0, -32768, 32768},
+		{0, math.MinInt32, math.MinInt32},
+		{-1, -1, -1},
+		{0, 100, 100},
+	}
+	for _, test := range tests {
+		if got := unsignedMaximum(test.minimum, test.maximum); got != test.want {
+			t.Errorf("unsignedMaximum(%d, %d) = %d, want %d", test.minimum, test.maximum, got, test.want)
+		}
+	}
+}
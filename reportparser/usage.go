@@ -0,0 +1,64 @@
+package reportparser
+
+import "github.com/telesma-app/hid/reportparser/usages"
+
+// ExtendedUsage is a usage qualified by its usage page, which occupies the
+// upper 16 bits.
+type ExtendedUsage uint32
+
+func NewExtendedUsage(page, id uint16) ExtendedUsage {
+	return ExtendedUsage(page)<<16 | ExtendedUsage(id)
+}
+
+func (u ExtendedUsage) Page() uint16 {
+	return uint16(u >> 16)
+}
+
+func (u ExtendedUsage) ID() uint16 {
+	return uint16(u)
+}
+
+// String returns the names of the usage page and usage from the HID Usage
+// Tables, such as "Generic Desktop / Game Pad".
+func (u ExtendedUsage) String() string {
+	return usages.Usage(u).String()
+}
+
+// maxUsages bounds Usage Minimum/Maximum expansion for a single main item.
+const maxUsages = 1 << 16
+
+// usageRange holds raw local usage values, which are extended usages when
+// their upper 16 bits are set.
+type usageRange struct {
+	minimum, maximum uint32
+}
+
+// completeRange appends a range once both Usage Minimum and Usage Maximum
+// were declared, in either order.
+func (l *localState) completeRange() {
+	if !l.hasUsageMinimum || !l.hasUsageMaximum {
+		return
+	}
+	l.usages = append(l.usages, usageRange{l.usageMinimum, l.usageMaximum})
+	l.hasUsageMinimum, l.hasUsageMaximum = false, false
+}
+
+// resolve expands the local usages into at most limit extended usages. Usages
+// without a usage page take page, the Usage Page in effect for the main item.
+// A range takes the usage page of its minimum.
+func (l *localState) resolve(page uint16, limit int) []ExtendedUsage {
+	var usages []ExtendedUsage
+	for _, r := range l.usages {
+		rangePage := page
+		if r.minimum>>16 != 0 {
+			rangePage = uint16(r.minimum >> 16)
+		}
+		for id := uint32(uint16(r.minimum)); id <= uint32(uint16(r.maximum)); id++ {
+			if len(usages) == limit {
+				return usages
+			}
+			usages = append(usages, NewExtendedUsage(rangePage, uint16(id)))
+		}
+	}
+	return usages
+}
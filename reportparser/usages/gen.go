@@ -0,0 +1,118 @@
+//go:build ignore
+
+// This program generates zusages.go from the usage table text file. Invoke it
+// as: go run gen.go -input=usages.txt -output=zusages.go
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"flag"
+	"fmt"
+	"go/format"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+)
+
+type usage struct {
+	id   uint64
+	name string
+}
+
+type page struct {
+	id     uint64
+	name   string
+	usages []usage
+}
+
+func main() {
+	input := flag.String("input", "usages.txt", "usage table text file")
+	output := flag.String("output", "zusages.go", "generated Go file")
+	flag.Parse()
+
+	pages, err := parse(*input)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "// Code generated by \"go run gen.go -input=%s -output=%s\"; DO NOT EDIT.\n\n", *input, *output)
+	fmt.Fprintf(&b, "package usages\n\n")
+
+	fmt.Fprintf(&b, "var pageNames = map[Page]string{\n")
+	for _, p := range pages {
+		fmt.Fprintf(&b, "0x%04X: %q,\n", p.id, p.name)
+	}
+	fmt.Fprintf(&b, "}\n\n")
+
+	fmt.Fprintf(&b, "var usageNames = map[Usage]string{\n")
+	for _, p := range pages {
+		if len(p.usages) == 0 {
+			continue
+		}
+		fmt.Fprintf(&b, "\n// %s\n", p.name)
+		for _, u := range p.usages {
+			fmt.Fprintf(&b, "0x%04X_%04X: %q,\n", p.id, u.id, u.name)
+		}
+	}
+	fmt.Fprintf(&b, "}\n")
+
+	src, err := format.Source(b.Bytes())
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := os.WriteFile(*output, src, 0o644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func parse(name string) ([]*page, error) {
+	f, err := os.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var pages []*page
+	seen := make(map[uint64]bool)
+	scanner := bufio.NewScanner(f)
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		isPage := false
+		if rest, ok := strings.CutPrefix(line, "page "); ok {
+			isPage, line = true, rest
+		}
+		idText, name, ok := strings.Cut(line, " ")
+		if !ok {
+			return nil, fmt.Errorf("%s:%d: missing name", f.Name(), n)
+		}
+		id, err := strconv.ParseUint(idText, 16, 16)
+		if err != nil {
+			return nil, fmt.Errorf("%s:%d: %w", f.Name(), n, err)
+		}
+
+		if isPage {
+			if seen[id] {
+				return nil, fmt.Errorf("%s:%d: duplicate page 0x%04X", f.Name(), n, id)
+			}
+			seen[id] = true
+			pages = append(pages, &page{id: id, name: name})
+			continue
+		}
+		if len(pages) == 0 {
+			return nil, fmt.Errorf("%s:%d: usage before the first page", f.Name(), n)
+		}
+		p := pages[len(pages)-1]
+		if len(p.usages) > 0 && p.usages[len(p.usages)-1].id >= id {
+			return nil, fmt.Errorf("%s:%d: usage 0x%04X is out of order", f.Name(), n, id)
+		}
+		p.usages = append(p.usages, usage{id: id, name: name})
+	}
+	return pages, scanner.Err()
+}
@@ -0,0 +1,160 @@
+//go:generate go run gen.go -input=usages.txt -output=zusages.go
+
+// Package usages names usage pages and usages from the HID Usage Tables.
+package usages
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Page is a usage page.
+type Page uint16
+
+const (
+	PageGenericDesktop Page = 0x01
+	PageKeyboard       Page = 0x07
+	PageLED            Page = 0x08
+	PageButton         Page = 0x09
+	PageOrdinal        Page = 0x0a
+	PageConsumer       Page = 0x0c
+	PageDigitizers     Page = 0x0d
+	PageUnicode        Page = 0x10
+	PageFIDO           Page = 0xf1d0
+
+	PageVendorMinimum Page = 0xff00
+)
+
+// Name returns the name of the page and whether it is known. Pages in the
+// vendor-defined range are known.
+func (p Page) Name() (string, bool) {
+	if p >= PageVendorMinimum {
+		return fmt.Sprintf("Vendor-defined 0x%04X", uint16(p)), true
+	}
+	name, ok := pageNames[p]
+	return name, ok
+}
+
+func (p Page) String() string {
+	if name, ok := p.Name(); ok {
+		return name
+	}
+	return fmt.Sprintf("Page 0x%04X", uint16(p))
+}
+
+// Usage is a usage qualified by its usage page, which occupies the upper 16
+// bits, as reportparser.ExtendedUsage.
+type Usage uint32
+
+func New(page Page, id uint16) Usage {
+	return Usage(page)<<16 | Usage(id)
+}
+
+func (u Usage) Page() Page {
+	return Page(u >> 16)
+}
+
+func (u Usage) ID() uint16 {
+	return uint16(u)
+}
+
+// Name returns the name of the usage within its page and whether it is known.
+// Usages of the Button, Ordinal and Unicode pages are numbered rather than
+// named and are always known.
+func (u Usage) Name() (string, bool) {
+	id := u.ID()
+	switch u.Page() {
+	case PageButton:
+		if id == 0 {
+			return "No Button Pressed", true
+		}
+		return fmt.Sprintf("Button %d", id), true
+	case PageOrdinal:
+		if id == 0 {
+			return "Reserved", true
+		}
+		return fmt.Sprintf("Instance %d", id), true
+	case PageUnicode:
+		return fmt.Sprintf("U+%04X", id), true
+	}
+	name, ok := usageNames[u]
+	return name, ok
+}
+
+// String returns the page and usage names separated by a slash, such as
+// "Generic Desktop / Game Pad". Unknown names are printed in hex.
+func (u Usage) String() string {
+	name, ok := u.Name()
+	if !ok {
+		name = fmt.Sprintf("0x%04X", u.ID())
+	}
+	return u.Page().String() + " / " + name
+}
+
+var (
+	pagesByName = sync.OnceValue(func() map[string]Page {
+		m := make(map[string]Page, len(pageNames))
+		for page, name := range pageNames {
+			m[name] = page
+		}
+		return m
+	})
+	usagesByName = sync.OnceValue(func() map[Page]map[string]Usage {
+		m := make(map[Page]map[string]Usage)
+		for usage, name := range usageNames {
+			if m[usage.Page()] == nil {
+				m[usage.Page()] = make(map[string]Usage)
+			}
+			m[usage.Page()][name] = usage
+		}
+		return m
+	})
+)
+
+// LookupPage returns the page with the given name. It is the inverse of
+// Page.Name.
+func LookupPage(name string) (Page, bool) {
+	if digits, ok := strings.CutPrefix(name, "Vendor-defined "); ok {
+		id, err := strconv.ParseUint(digits, 0, 16)
+		if err != nil {
+			return 0, false
+		}
+		page := Page(id)
+		if n, ok := page.Name(); !ok || n != name {
+			return 0, false
+		}
+		return page, true
+	}
+	page, ok := pagesByName()[name]
+	return page, ok
+}
+
+// Lookup returns the usage of page with the given name. It is the inverse of
+// Usage.Name.
+func Lookup(page Page, name string) (Usage, bool) {
+	var digits string
+	base := 10
+	switch page {
+	case PageButton:
+		digits, _ = strings.CutPrefix(name, "Button ")
+	case PageOrdinal:
+		digits, _ = strings.CutPrefix(name, "Instance ")
+	case PageUnicode:
+		digits, _ = strings.CutPrefix(name, "U+")
+		base = 16
+	default:
+		usage, ok := usagesByName()[page][name]
+		return usage, ok
+	}
+
+	usage := New(page, 0)
+	if id, err := strconv.ParseUint(digits, base, 16); err == nil {
+		usage = New(page, uint16(id))
+	}
+	if n, ok := usage.Name(); !ok || n != name {
+		return 0, false
+	}
+	return usage, true
+}
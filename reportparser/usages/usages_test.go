@@ -0,0 +1,70 @@
+package usages
+
+import "testing"
+
+func TestUsageString(t *testing.T) {
+	tests := []struct {
+		usage Usage
+		want  string
+	}{
+		{New(PageGenericDesktop, 0x05), "Generic Desktop / Game Pad"},
+		{New(PageKeyboard, 0xe1), "Keyboard/Keypad / Keyboard LeftShift"},
+		{New(PageConsumer, 0xcd), "Consumer / Play/Pause"},
+		{New(PageFIDO, 0x01), "FIDO Alliance / U2F Authenticator Device"},
+		{New(PageButton, 0), "Button / No Button Pressed"},
+		{New(PageButton, 3), "Button / Button 3"},
+		{New(PageOrdinal, 2), "Ordinal / Instance 2"},
+		{New(PageUnicode, 0x20ac), "Unicode / U+20AC"},
+		{New(0xff00, 0x01), "Vendor-defined 0xFF00 / 0x0001"},
+		{New(PageGenericDesktop, 0x2f), "Generic Desktop / 0x002F"},
+		{New(0x1234, 0x01), "Page 0x1234 / 0x0001"},
+	}
+
+	for _, test := range tests {
+		if got := test.usage.String(); got != test.want {
+			t.Errorf("Usage(%#08x).String() = %q, want %q", uint32(test.usage), got, test.want)
+		}
+	}
+}
+
+func TestPageName(t *testing.T) {
+	if name, ok := Page(0x20).Name(); !ok || name != "Sensors" {
+		t.Fatalf("Page(0x20).Name() = %q, %v", name, ok)
+	}
+	if _, ok := Page(0x13).Name(); ok {
+		t.Fatal("reserved page 0x13 has a name")
+	}
+}
+
+func TestLookup(t *testing.T) {
+	for usage, name := range usageNames {
+		if got, ok := Lookup(usage.Page(), name); !ok || got != usage {
+			t.Errorf("Lookup(%v, %q) = %#08x, %v; want %#08x", usage.Page(), name, uint32(got), ok, uint32(usage))
+		}
+	}
+	for _, usage := range []Usage{New(PageButton, 0), New(PageButton, 12), New(PageOrdinal, 0), New(PageOrdinal, 3), New(PageUnicode, 0xe9)} {
+		name, _ := usage.Name()
+		if got, ok := Lookup(usage.Page(), name); !ok || got != usage {
+			t.Errorf("Lookup(%v, %q) = %#08x, %v; want %#08x", usage.Page(), name, uint32(got), ok, uint32(usage))
+		}
+	}
+	for _, name := range []string{"Button 03", "Button", "Instance x", "U+zz"} {
+		if usage, ok := Lookup(PageButton, name); ok {
+			t.Errorf("Lookup(Button, %q) = %#08x, want no usage", name, uint32(usage))
+		}
+	}
+}
+
+func TestLookupPage(t *testing.T) {
+	for page, name := range pageNames {
+		if got, ok := LookupPage(name); !ok || got != page {
+			t.Errorf("LookupPage(%q) = %#04x, %v; want %#04x", name, uint16(got), ok, uint16(page))
+		}
+	}
+	if page, ok := LookupPage("Vendor-defined 0xFF01"); !ok || page != 0xff01 {
+		t.Errorf("LookupPage(vendor) = %#04x, %v", uint16(page), ok)
+	}
+	if page, ok := LookupPage("Vendor-defined 0x0001"); ok {
+		t.Errorf("LookupPage() accepted a vendor name outside the vendor range: %#04x", uint16(page))
+	}
+}
@@ -0,0 +1,1334 @@
+// Code generated by "go run gen.go -input=usages.txt -output=zusages.go"; DO NOT EDIT.
+
+package usages
+
+var pageNames = map[Page]string{
+	0x0001: "Generic Desktop",
+	0x0002: "Simulation Controls",
+	0x0003: "VR Controls",
+	0x0004: "Sport Controls",
+	0x0005: "Game Controls",
+	0x0006: "Generic Device Controls",
+	0x0007: "Keyboard/Keypad",
+	0x0008: "LED",
+	0x0009: "Button",
+	0x000A: "Ordinal",
+	0x000B: "Telephony Device",
+	0x000C: "Consumer",
+	0x000D: "Digitizers",
+	0x000E: "Haptics",
+	0x000F: "Physical Input Device",
+	0x0010: "Unicode",
+	0x0011: "SoC",
+	0x0012: "Eye and Head Trackers",
+	0x0014: "Auxiliary Display",
+	0x0020: "Sensors",
+	0x0040: "Medical Instrument",
+	0x0041: "Braille Display",
+	0x0059: "Lighting And Illumination",
+	0x0080: "Monitor",
+	0x0081: "Monitor Enumerated",
+	0x0082: "VESA Virtual Controls",
+	0x0084: "Power",
+	0x0085: "Battery System",
+	0x008C: "Barcode Scanner",
+	0x008D: "Scales",
+	0x008E: "Magnetic Stripe Reader",
+	0x0090: "Camera Control",
+	0x0091: "Arcade",
+	0x0092: "Gaming Device",
+	0xF1D0: "FIDO Alliance",
+}
+
+var usageNames = map[Usage]string{
+
+	// Generic Desktop
+	0x0001_0001: "Pointer",
+	0x0001_0002: "Mouse",
+	0x0001_0004: "Joystick",
+	0x0001_0005: "Game Pad",
+	0x0001_0006: "Keyboard",
+	0x0001_0007: "Keypad",
+	0x0001_0008: "Multi-axis Controller",
+	0x0001_0009: "Tablet PC System Controls",
+	0x0001_000A: "Water Cooling Device",
+	0x0001_000B: "Computer Chassis Device",
+	0x0001_000C: "Wireless Radio Controls",
+	0x0001_000D: "Portable Device Control",
+	0x0001_000E: "System Multi-Axis Controller",
+	0x0001_000F: "Spatial Controller",
+	0x0001_0010: "Assistive Control",
+	0x0001_0011: "Device Dock",
+	0x0001_0012: "Dockable Device",
+	0x0001_0013: "Call State Management Control",
+	0x0001_0030: "X",
+	0x0001_0031: "Y",
+	0x0001_0032: "Z",
+	0x0001_0033: "Rx",
+	0x0001_0034: "Ry",
+	0x0001_0035: "Rz",
+	0x0001_0036: "Slider",
+	0x0001_0037: "Dial",
+	0x0001_0038: "Wheel",
+	0x0001_0039: "Hat Switch",
+	0x0001_003A: "Counted Buffer",
+	0x0001_003B: "Byte Count",
+	0x0001_003C: "Motion Wakeup",
+	0x0001_003D: "Start",
+	0x0001_003E: "Select",
+	0x0001_0040: "Vx",
+	0x0001_0041: "Vy",
+	0x0001_0042: "Vz",
+	0x0001_0043: "Vbrx",
+	0x0001_0044: "Vbry",
+	0x0001_0045: "Vbrz",
+	0x0001_0046: "Vno",
+	0x0001_0047: "Feature Notification",
+	0x0001_0048: "Resolution Multiplier",
+	0x0001_0049: "Qx",
+	0x0001_004A: "Qy",
+	0x0001_004B: "Qz",
+	0x0001_004C: "Qw",
+	0x0001_0080: "System Control",
+	0x0001_0081: "System Power Down",
+	0x0001_0082: "System Sleep",
+	0x0001_0083: "System Wake Up",
+	0x0001_0084: "System Context Menu",
+	0x0001_0085: "System Main Menu",
+	0x0001_0086: "System App Menu",
+	0x0001_0087: "System Menu Help",
+	0x0001_0088: "System Menu Exit",
+	0x0001_0089: "System Menu Select",
+	0x0001_008A: "System Menu Right",
+	0x0001_008B: "System Menu Left",
+	0x0001_008C: "System Menu Up",
+	0x0001_008D: "System Menu Down",
+	0x0001_008E: "System Cold Restart",
+	0x0001_008F: "System Warm Restart",
+	0x0001_0090: "D-pad Up",
+	0x0001_0091: "D-pad Down",
+	0x0001_0092: "D-pad Right",
+	0x0001_0093: "D-pad Left",
+	0x0001_0094: "Index Trigger",
+	0x0001_0095: "Palm Trigger",
+	0x0001_0096: "Thumbstick",
+	0x0001_0097: "System Function Shift",
+	0x0001_0098: "System Function Shift Lock",
+	0x0001_0099: "System Function Shift Lock Indicator",
+	0x0001_009A: "System Dismiss Notification",
+	0x0001_009B: "System Do Not Disturb",
+	0x0001_00A0: "System Dock",
+	0x0001_00A1: "System Undock",
+	0x0001_00A2: "System Setup",
+	0x0001_00A3: "System Break",
+	0x0001_00A4: "System Debugger Break",
+	0x0001_00A5: "Application Break",
+	0x0001_00A6: "Application Debugger Break",
+	0x0001_00A7: "System Speaker Mute",
+	0x0001_00A8: "System Hibernate",
+	0x0001_00A9: "System Microphone Mute",
+	0x0001_00B0: "System Display Invert",
+	0x0001_00B1: "System Display Internal",
+	0x0001_00B2: "System Display External",
+	0x0001_00B3: "System Display Both",
+	0x0001_00B4: "System Display Dual",
+	0x0001_00B5: "System Display Toggle Int/Ext Mode",
+	0x0001_00B6: "System Display Swap Primary/Secondary",
+	0x0001_00B7: "System Display Toggle LCD Autoscale",
+	0x0001_00C0: "Sensor Zone",
+	0x0001_00C1: "RPM",
+	0x0001_00C2: "Coolant Level",
+	0x0001_00C3: "Coolant Critical Level",
+	0x0001_00C4: "Coolant Pump",
+	0x0001_00C5: "Chassis Enclosure",
+	0x0001_00C6: "Wireless Radio Button",
+	0x0001_00C7: "Wireless Radio LED",
+	0x0001_00C8: "Wireless Radio Slider Switch",
+	0x0001_00C9: "System Display Rotation Lock Button",
+	0x0001_00CA: "System Display Rotation Lock Slider Switch",
+	0x0001_00CB: "Control Enable",
+	0x0001_00D0: "Dockable Device Unique ID",
+	0x0001_00D1: "Dockable Device Vendor ID",
+	0x0001_00D2: "Dockable Device Primary Usage Page",
+	0x0001_00D3: "Dockable Device Primary Usage ID",
+	0x0001_00D4: "Dockable Device Docking State",
+	0x0001_00D5: "Dockable Device Display Occlusion",
+	0x0001_00D6: "Dockable Device Object Type",
+	0x0001_00E0: "Call Active LED",
+	0x0001_00E1: "Call Mute Toggle",
+	0x0001_00E2: "Call Mute LED",
+
+	// Simulation Controls
+	0x0002_0001: "Flight Simulation Device",
+	0x0002_0002: "Automobile Simulation Device",
+	0x0002_0003: "Tank Simulation Device",
+	0x0002_0004: "Spaceship Simulation Device",
+	0x0002_0005: "Submarine Simulation Device",
+	0x0002_0006: "Sailing Simulation Device",
+	0x0002_0007: "Motorcycle Simulation Device",
+	0x0002_0008: "Sports Simulation Device",
+	0x0002_0009: "Airplane Simulation Device",
+	0x0002_000A: "Helicopter Simulation Device",
+	0x0002_000B: "Magic Carpet Simulation Device",
+	0x0002_000C: "Bicycle Simulation Device",
+	0x0002_0020: "Flight Control Stick",
+	0x0002_0021: "Flight Stick",
+	0x0002_0022: "Cyclic Control",
+	0x0002_0023: "Cyclic Trim",
+	0x0002_0024: "Flight Yoke",
+	0x0002_0025: "Track Control",
+	0x0002_00B0: "Aileron",
+	0x0002_00B1: "Aileron Trim",
+	0x0002_00B2: "Anti-Torque Control",
+	0x0002_00B3: "Autopilot Enable",
+	0x0002_00B4: "Chaff Release",
+	0x0002_00B5: "Collective Control",
+	0x0002_00B6: "Dive Brake",
+	0x0002_00B7: "Electronic Countermeasures",
+	0x0002_00B8: "Elevator",
+	0x0002_00B9: "Elevator Trim",
+	0x0002_00BA: "Rudder",
+	0x0002_00BB: "Throttle",
+	0x0002_00BC: "Flight Communications",
+	0x0002_00BD: "Flare Release",
+	0x0002_00BE: "Landing Gear",
+	0x0002_00BF: "Toe Brake",
+	0x0002_00C0: "Trigger",
+	0x0002_00C1: "Weapons Arm",
+	0x0002_00C2: "Weapons Select",
+	0x0002_00C3: "Wing Flaps",
+	0x0002_00C4: "Accelerator",
+	0x0002_00C5: "Brake",
+	0x0002_00C6: "Clutch",
+	0x0002_00C7: "Shifter",
+	0x0002_00C8: "Steering",
+	0x0002_00C9: "Turret Direction",
+	0x0002_00CA: "Barrel Elevation",
+	0x0002_00CB: "Dive Plane",
+	0x0002_00CC: "Ballast",
+	0x0002_00CD: "Bicycle Crank",
+	0x0002_00CE: "Handle Bars",
+	0x0002_00CF: "Front Brake",
+	0x0002_00D0: "Rear Brake",
+
+	// VR Controls
+	0x0003_0001: "Belt",
+	0x0003_0002: "Body Suit",
+	0x0003_0003: "Flexor",
+	0x0003_0004: "Glove",
+	0x0003_0005: "Head Tracker",
+	0x0003_0006: "Head Mounted Display",
+	0x0003_0007: "Hand Tracker",
+	0x0003_0008: "Oculometer",
+	0x0003_0009: "Vest",
+	0x0003_000A: "Animatronic Device",
+	0x0003_0020: "Stereo Enable",
+	0x0003_0021: "Display Enable",
+
+	// Sport Controls
+	0x0004_0001: "Baseball Bat",
+	0x0004_0002: "Golf Club",
+	0x0004_0003: "Rowing Machine",
+	0x0004_0004: "Treadmill",
+	0x0004_0030: "Oar",
+	0x0004_0031: "Slope",
+	0x0004_0032: "Rate",
+	0x0004_0033: "Stick Speed",
+	0x0004_0034: "Stick Face Angle",
+	0x0004_0035: "Stick Heel/Toe",
+	0x0004_0036: "Stick Follow Through",
+	0x0004_0037: "Stick Tempo",
+	0x0004_0038: "Stick Type",
+	0x0004_0039: "Stick Height",
+
+	// Game Controls
+	0x0005_0001: "3D Game Controller",
+	0x0005_0002: "Pinball Device",
+	0x0005_0003: "Gun Device",
+	0x0005_0020: "Point of View",
+	0x0005_0021: "Turn Right/Left",
+	0x0005_0022: "Pitch Forward/Backward",
+	0x0005_0023: "Roll Right/Left",
+	0x0005_0024: "Move Right/Left",
+	0x0005_0025: "Move Forward/Backward",
+	0x0005_0026: "Move Up/Down",
+	0x0005_0027: "Lean Right/Left",
+	0x0005_0028: "Lean Forward/Backward",
+	0x0005_0029: "Height of POV",
+	0x0005_002A: "Flipper",
+	0x0005_002B: "Secondary Flipper",
+	0x0005_002C: "Bump",
+	0x0005_002D: "New Game",
+	0x0005_002E: "Shoot Ball",
+	0x0005_002F: "Player",
+	0x0005_0030: "Gun Bolt",
+	0x0005_0031: "Gun Clip",
+	0x0005_0032: "Gun Selector",
+	0x0005_0033: "Gun Single Shot",
+	0x0005_0034: "Gun Burst",
+	0x0005_0035: "Gun Automatic",
+	0x0005_0036: "Gun Safety",
+	0x0005_0037: "Gamepad Fire/Jump",
+	0x0005_0039: "Gamepad Trigger",
+	0x0005_003A: "Form-fitting Gamepad",
+
+	// Generic Device Controls
+	0x0006_0001: "Background/Nonuser Controls",
+	0x0006_0020: "Battery Strength",
+	0x0006_0021: "Wireless Channel",
+	0x0006_0022: "Wireless ID",
+	0x0006_0023: "Discover Wireless Control",
+	0x0006_0024: "Security Code Character Entered",
+	0x0006_0025: "Security Code Character Erased",
+	0x0006_0026: "Security Code Cleared",
+	0x0006_0027: "Sequence ID",
+	0x0006_0028: "Sequence ID Reset",
+	0x0006_0029: "RF Signal Strength",
+	0x0006_002A: "Software Version",
+	0x0006_002B: "Protocol Version",
+	0x0006_002C: "Hardware Version",
+	0x0006_002D: "Major",
+	0x0006_002E: "Minor",
+	0x0006_002F: "Revision",
+	0x0006_0030: "Handedness",
+	0x0006_0031: "Either Hand",
+	0x0006_0032: "Left Hand",
+	0x0006_0033: "Right Hand",
+	0x0006_0034: "Both Hands",
+	0x0006_0040: "Grip Pose Offset",
+	0x0006_0041: "Pointer Pose Offset",
+
+	// Keyboard/Keypad
+	0x0007_0001: "Keyboard ErrorRollOver",
+	0x0007_0002: "Keyboard POSTFail",
+	0x0007_0003: "Keyboard ErrorUndefined",
+	0x0007_0004: "Keyboard a and A",
+	0x0007_0005: "Keyboard b and B",
+	0x0007_0006: "Keyboard c and C",
+	0x0007_0007: "Keyboard d and D",
+	0x0007_0008: "Keyboard e and E",
+	0x0007_0009: "Keyboard f and F",
+	0x0007_000A: "Keyboard g and G",
+	0x0007_000B: "Keyboard h and H",
+	0x0007_000C: "Keyboard i and I",
+	0x0007_000D: "Keyboard j and J",
+	0x0007_000E: "Keyboard k and K",
+	0x0007_000F: "Keyboard l and L",
+	0x0007_0010: "Keyboard m and M",
+	0x0007_0011: "Keyboard n and N",
+	0x0007_0012: "Keyboard o and O",
+	0x0007_0013: "Keyboard p and P",
+	0x0007_0014: "Keyboard q and Q",
+	0x0007_0015: "Keyboard r and R",
+	0x0007_0016: "Keyboard s and S",
+	0x0007_0017: "Keyboard t and T",
+	0x0007_0018: "Keyboard u and U",
+	0x0007_0019: "Keyboard v and V",
+	0x0007_001A: "Keyboard w and W",
+	0x0007_001B: "Keyboard x and X",
+	0x0007_001C: "Keyboard y and Y",
+	0x0007_001D: "Keyboard z and Z",
+	0x0007_001E: "Keyboard 1 and !",
+	0x0007_001F: "Keyboard 2 and @",
+	0x0007_0020: "Keyboard 3 and #",
+	0x0007_0021: "Keyboard 4 and $",
+	0x0007_0022: "Keyboard 5 and %",
+	0x0007_0023: "Keyboard 6 and ^",
+	0x0007_0024: "Keyboard 7 and &",
+	0x0007_0025: "Keyboard 8 and *",
+	0x0007_0026: "Keyboard 9 and (",
+	0x0007_0027: "Keyboard 0 and )",
+	0x0007_0028: "Keyboard Return (ENTER)",
+	0x0007_0029: "Keyboard ESCAPE",
+	0x0007_002A: "Keyboard DELETE (Backspace)",
+	0x0007_002B: "Keyboard Tab",
+	0x0007_002C: "Keyboard Spacebar",
+	0x0007_002D: "Keyboard - and _",
+	0x0007_002E: "Keyboard = and +",
+	0x0007_002F: "Keyboard [ and {",
+	0x0007_0030: "Keyboard ] and }",
+	0x0007_0031: "Keyboard \\ and |",
+	0x0007_0032: "Keyboard Non-US # and ~",
+	0x0007_0033: "Keyboard ; and :",
+	0x0007_0034: "Keyboard ' and \"",
+	0x0007_0035: "Keyboard Grave Accent and Tilde",
+	0x0007_0036: "Keyboard , and <",
+	0x0007_0037: "Keyboard . and >",
+	0x0007_0038: "Keyboard / and ?",
+	0x0007_0039: "Keyboard Caps Lock",
+	0x0007_003A: "Keyboard F1",
+	0x0007_003B: "Keyboard F2",
+	0x0007_003C: "Keyboard F3",
+	0x0007_003D: "Keyboard F4",
+	0x0007_003E: "Keyboard F5",
+	0x0007_003F: "Keyboard F6",
+	0x0007_0040: "Keyboard F7",
+	0x0007_0041: "Keyboard F8",
+	0x0007_0042: "Keyboard F9",
+	0x0007_0043: "Keyboard F10",
+	0x0007_0044: "Keyboard F11",
+	0x0007_0045: "Keyboard F12",
+	0x0007_0046: "Keyboard PrintScreen",
+	0x0007_0047: "Keyboard Scroll Lock",
+	0x0007_0048: "Keyboard Pause",
+	0x0007_0049: "Keyboard Insert",
+	0x0007_004A: "Keyboard Home",
+	0x0007_004B: "Keyboard PageUp",
+	0x0007_004C: "Keyboard Delete Forward",
+	0x0007_004D: "Keyboard End",
+	0x0007_004E: "Keyboard PageDown",
+	0x0007_004F: "Keyboard RightArrow",
+	0x0007_0050: "Keyboard LeftArrow",
+	0x0007_0051: "Keyboard DownArrow",
+	0x0007_0052: "Keyboard UpArrow",
+	0x0007_0053: "Keypad Num Lock and Clear",
+	0x0007_0054: "Keypad /",
+	0x0007_0055: "Keypad *",
+	0x0007_0056: "Keypad -",
+	0x0007_0057: "Keypad +",
+	0x0007_0058: "Keypad ENTER",
+	0x0007_0059: "Keypad 1 and End",
+	0x0007_005A: "Keypad 2 and Down Arrow",
+	0x0007_005B: "Keypad 3 and PageDn",
+	0x0007_005C: "Keypad 4 and Left Arrow",
+	0x0007_005D: "Keypad 5",
+	0x0007_005E: "Keypad 6 and Right Arrow",
+	0x0007_005F: "Keypad 7 and Home",
+	0x0007_0060: "Keypad 8 and Up Arrow",
+	0x0007_0061: "Keypad 9 and PageUp",
+	0x0007_0062: "Keypad 0 and Insert",
+	0x0007_0063: "Keypad . and Delete",
+	0x0007_0064: "Keyboard Non-US \\ and |",
+	0x0007_0065: "Keyboard Application",
+	0x0007_0066: "Keyboard Power",
+	0x0007_0067: "Keypad =",
+	0x0007_0068: "Keyboard F13",
+	0x0007_0069: "Keyboard F14",
+	0x0007_006A: "Keyboard F15",
+	0x0007_006B: "Keyboard F16",
+	0x0007_006C: "Keyboard F17",
+	0x0007_006D: "Keyboard F18",
+	0x0007_006E: "Keyboard F19",
+	0x0007_006F: "Keyboard F20",
+	0x0007_0070: "Keyboard F21",
+	0x0007_0071: "Keyboard F22",
+	0x0007_0072: "Keyboard F23",
+	0x0007_0073: "Keyboard F24",
+	0x0007_0074: "Keyboard Execute",
+	0x0007_0075: "Keyboard Help",
+	0x0007_0076: "Keyboard Menu",
+	0x0007_0077: "Keyboard Select",
+	0x0007_0078: "Keyboard Stop",
+	0x0007_0079: "Keyboard Again",
+	0x0007_007A: "Keyboard Undo",
+	0x0007_007B: "Keyboard Cut",
+	0x0007_007C: "Keyboard Copy",
+	0x0007_007D: "Keyboard Paste",
+	0x0007_007E: "Keyboard Find",
+	0x0007_007F: "Keyboard Mute",
+	0x0007_0080: "Keyboard Volume Up",
+	0x0007_0081: "Keyboard Volume Down",
+	0x0007_0082: "Keyboard Locking Caps Lock",
+	0x0007_0083: "Keyboard Locking Num Lock",
+	0x0007_0084: "Keyboard Locking Scroll Lock",
+	0x0007_0085: "Keypad Comma",
+	0x0007_0086: "Keypad Equal Sign",
+	0x0007_0087: "Keyboard International1",
+	0x0007_0088: "Keyboard International2",
+	0x0007_0089: "Keyboard International3",
+	0x0007_008A: "Keyboard International4",
+	0x0007_008B: "Keyboard International5",
+	0x0007_008C: "Keyboard International6",
+	0x0007_008D: "Keyboard International7",
+	0x0007_008E: "Keyboard International8",
+	0x0007_008F: "Keyboard International9",
+	0x0007_0090: "Keyboard LANG1",
+	0x0007_0091: "Keyboard LANG2",
+	0x0007_0092: "Keyboard LANG3",
+	0x0007_0093: "Keyboard LANG4",
+	0x0007_0094: "Keyboard LANG5",
+	0x0007_0095: "Keyboard LANG6",
+	0x0007_0096: "Keyboard LANG7",
+	0x0007_0097: "Keyboard LANG8",
+	0x0007_0098: "Keyboard LANG9",
+	0x0007_0099: "Keyboard Alternate Erase",
+	0x0007_009A: "Keyboard SysReq/Attention",
+	0x0007_009B: "Keyboard Cancel",
+	0x0007_009C: "Keyboard Clear",
+	0x0007_009D: "Keyboard Prior",
+	0x0007_009E: "Keyboard Return",
+	0x0007_009F: "Keyboard Separator",
+	0x0007_00A0: "Keyboard Out",
+	0x0007_00A1: "Keyboard Oper",
+	0x0007_00A2: "Keyboard Clear/Again",
+	0x0007_00A3: "Keyboard CrSel/Props",
+	0x0007_00A4: "Keyboard ExSel",
+	0x0007_00B0: "Keypad 00",
+	0x0007_00B1: "Keypad 000",
+	0x0007_00B2: "Thousands Separator",
+	0x0007_00B3: "Decimal Separator",
+	0x0007_00B4: "Currency Unit",
+	0x0007_00B5: "Currency Sub-unit",
+	0x0007_00B6: "Keypad (",
+	0x0007_00B7: "Keypad )",
+	0x0007_00B8: "Keypad {",
+	0x0007_00B9: "Keypad }",
+	0x0007_00BA: "Keypad Tab",
+	0x0007_00BB: "Keypad Backspace",
+	0x0007_00BC: "Keypad A",
+	0x0007_00BD: "Keypad B",
+	0x0007_00BE: "Keypad C",
+	0x0007_00BF: "Keypad D",
+	0x0007_00C0: "Keypad E",
+	0x0007_00C1: "Keypad F",
+	0x0007_00C2: "Keypad XOR",
+	0x0007_00C3: "Keypad ^",
+	0x0007_00C4: "Keypad %",
+	0x0007_00C5: "Keypad <",
+	0x0007_00C6: "Keypad >",
+	0x0007_00C7: "Keypad &",
+	0x0007_00C8: "Keypad &&",
+	0x0007_00C9: "Keypad |",
+	0x0007_00CA: "Keypad ||",
+	0x0007_00CB: "Keypad :",
+	0x0007_00CC: "Keypad #",
+	0x0007_00CD: "Keypad Space",
+	0x0007_00CE: "Keypad @",
+	0x0007_00CF: "Keypad !",
+	0x0007_00D0: "Keypad Memory Store",
+	0x0007_00D1: "Keypad Memory Recall",
+	0x0007_00D2: "Keypad Memory Clear",
+	0x0007_00D3: "Keypad Memory Add",
+	0x0007_00D4: "Keypad Memory Subtract",
+	0x0007_00D5: "Keypad Memory Multiply",
+	0x0007_00D6: "Keypad Memory Divide",
+	0x0007_00D7: "Keypad +/-",
+	0x0007_00D8: "Keypad Clear",
+	0x0007_00D9: "Keypad Clear Entry",
+	0x0007_00DA: "Keypad Binary",
+	0x0007_00DB: "Keypad Octal",
+	0x0007_00DC: "Keypad Decimal",
+	0x0007_00DD: "Keypad Hexadecimal",
+	0x0007_00E0: "Keyboard LeftControl",
+	0x0007_00E1: "Keyboard LeftShift",
+	0x0007_00E2: "Keyboard LeftAlt",
+	0x0007_00E3: "Keyboard Left GUI",
+	0x0007_00E4: "Keyboard RightControl",
+	0x0007_00E5: "Keyboard RightShift",
+	0x0007_00E6: "Keyboard RightAlt",
+	0x0007_00E7: "Keyboard Right GUI",
+
+	// LED
+	0x0008_0001: "Num Lock",
+	0x0008_0002: "Caps Lock",
+	0x0008_0003: "Scroll Lock",
+	0x0008_0004: "Compose",
+	0x0008_0005: "Kana",
+	0x0008_0006: "Power",
+	0x0008_0007: "Shift",
+	0x0008_0008: "Do Not Disturb",
+	0x0008_0009: "Mute",
+	0x0008_000A: "Tone Enable",
+	0x0008_000B: "High Cut Filter",
+	0x0008_000C: "Low Cut Filter",
+	0x0008_000D: "Equalizer Enable",
+	0x0008_000E: "Sound Field On",
+	0x0008_000F: "Surround On",
+	0x0008_0010: "Repeat",
+	0x0008_0011: "Stereo",
+	0x0008_0012: "Sampling Rate Detect",
+	0x0008_0013: "Spinning",
+	0x0008_0014: "CAV",
+	0x0008_0015: "CLV",
+	0x0008_0016: "Recording Format Detect",
+	0x0008_0017: "Off-Hook",
+	0x0008_0018: "Ring",
+	0x0008_0019: "Message Waiting",
+	0x0008_001A: "Data Mode",
+	0x0008_001B: "Battery Operation",
+	0x0008_001C: "Battery OK",
+	0x0008_001D: "Battery Low",
+	0x0008_001E: "Speaker",
+	0x0008_001F: "Headset",
+	0x0008_0020: "Hold",
+	0x0008_0021: "Microphone",
+	0x0008_0022: "Coverage",
+	0x0008_0023: "Night Mode",
+	0x0008_0024: "Send Calls",
+	0x0008_0025: "Call Pickup",
+	0x0008_0026: "Conference",
+	0x0008_0027: "Stand-by",
+	0x0008_0028: "Camera On",
+	0x0008_0029: "Camera Off",
+	0x0008_002A: "On-Line",
+	0x0008_002B: "Off-Line",
+	0x0008_002C: "Busy",
+	0x0008_002D: "Ready",
+	0x0008_002E: "Paper-Out",
+	0x0008_002F: "Paper-Jam",
+	0x0008_0030: "Remote",
+	0x0008_0031: "Forward",
+	0x0008_0032: "Reverse",
+	0x0008_0033: "Stop",
+	0x0008_0034: "Rewind",
+	0x0008_0035: "Fast Forward",
+	0x0008_0036: "Play",
+	0x0008_0037: "Pause",
+	0x0008_0038: "Record",
+	0x0008_0039: "Error",
+	0x0008_003A: "Usage Selected Indicator",
+	0x0008_003B: "Usage In Use Indicator",
+	0x0008_003C: "Usage Multi Mode Indicator",
+	0x0008_003D: "Indicator On",
+	0x0008_003E: "Indicator Flash",
+	0x0008_003F: "Indicator Slow Blink",
+	0x0008_0040: "Indicator Fast Blink",
+	0x0008_0041: "Indicator Off",
+	0x0008_0042: "Flash On Time",
+	0x0008_0043: "Slow Blink On Time",
+	0x0008_0044: "Slow Blink Off Time",
+	0x0008_0045: "Fast Blink On Time",
+	0x0008_0046: "Fast Blink Off Time",
+	0x0008_0047: "Usage Indicator Color",
+	0x0008_0048: "Indicator Red",
+	0x0008_0049: "Indicator Green",
+	0x0008_004A: "Indicator Amber",
+	0x0008_004B: "Generic Indicator",
+	0x0008_004C: "System Suspend",
+	0x0008_004D: "External Power Connected",
+
+	// Telephony Device
+	0x000B_0001: "Phone",
+	0x000B_0002: "Answering Machine",
+	0x000B_0003: "Message Controls",
+	0x000B_0004: "Handset",
+	0x000B_0005: "Headset",
+	0x000B_0006: "Telephony Key Pad",
+	0x000B_0007: "Programmable Button",
+	0x000B_0020: "Hook Switch",
+	0x000B_0021: "Flash",
+	0x000B_0022: "Feature",
+	0x000B_0023: "Hold",
+	0x000B_0024: "Redial",
+	0x000B_0025: "Transfer",
+	0x000B_0026: "Drop",
+	0x000B_0027: "Park",
+	0x000B_0028: "Forward Calls",
+	0x000B_0029: "Alternate Function",
+	0x000B_002A: "Line",
+	0x000B_002B: "Speaker Phone",
+	0x000B_002C: "Conference",
+	0x000B_002D: "Ring Enable",
+	0x000B_002E: "Ring Select",
+	0x000B_002F: "Phone Mute",
+	0x000B_0030: "Caller ID",
+	0x000B_0031: "Send",
+	0x000B_0050: "Speed Dial",
+	0x000B_0051: "Store Number",
+	0x000B_0052: "Recall Number",
+	0x000B_0053: "Phone Directory",
+	0x000B_0070: "Voice Mail",
+	0x000B_0071: "Screen Calls",
+	0x000B_0072: "Do Not Disturb",
+	0x000B_0073: "Message",
+	0x000B_0074: "Answer On/Off",
+	0x000B_0090: "Inside Dial Tone",
+	0x000B_0091: "Outside Dial Tone",
+	0x000B_0092: "Inside Ring Tone",
+	0x000B_0093: "Outside Ring Tone",
+	0x000B_0094: "Priority Ring Tone",
+	0x000B_0095: "Inside Ringback",
+	0x000B_0096: "Priority Ringback",
+	0x000B_0097: "Line Busy Tone",
+	0x000B_0098: "Reorder Tone",
+	0x000B_0099: "Call Waiting Tone",
+	0x000B_009A: "Confirmation Tone 1",
+	0x000B_009B: "Confirmation Tone 2",
+	0x000B_009C: "Tones Off",
+	0x000B_009D: "Outside Ringback",
+	0x000B_009E: "Ringer",
+	0x000B_00B0: "Phone Key 0",
+	0x000B_00B1: "Phone Key 1",
+	0x000B_00B2: "Phone Key 2",
+	0x000B_00B3: "Phone Key 3",
+	0x000B_00B4: "Phone Key 4",
+	0x000B_00B5: "Phone Key 5",
+	0x000B_00B6: "Phone Key 6",
+	0x000B_00B7: "Phone Key 7",
+	0x000B_00B8: "Phone Key 8",
+	0x000B_00B9: "Phone Key 9",
+	0x000B_00BA: "Phone Key Star",
+	0x000B_00BB: "Phone Key Pound",
+	0x000B_00BC: "Phone Key A",
+	0x000B_00BD: "Phone Key B",
+	0x000B_00BE: "Phone Key C",
+	0x000B_00BF: "Phone Key D",
+
+	// Consumer
+	0x000C_0001: "Consumer Control",
+	0x000C_0002: "Numeric Key Pad",
+	0x000C_0003: "Programmable Buttons",
+	0x000C_0004: "Microphone",
+	0x000C_0005: "Headphone",
+	0x000C_0006: "Graphic Equalizer",
+	0x000C_0020: "+10",
+	0x000C_0021: "+100",
+	0x000C_0022: "AM/PM",
+	0x000C_0030: "Power",
+	0x000C_0031: "Reset",
+	0x000C_0032: "Sleep",
+	0x000C_0033: "Sleep After",
+	0x000C_0034: "Sleep Mode",
+	0x000C_0035: "Illumination",
+	0x000C_0036: "Function Buttons",
+	0x000C_0040: "Menu",
+	0x000C_0041: "Menu Pick",
+	0x000C_0042: "Menu Up",
+	0x000C_0043: "Menu Down",
+	0x000C_0044: "Menu Left",
+	0x000C_0045: "Menu Right",
+	0x000C_0046: "Menu Escape",
+	0x000C_0047: "Menu Value Increase",
+	0x000C_0048: "Menu Value Decrease",
+	0x000C_0060: "Data On Screen",
+	0x000C_0061: "Closed Caption",
+	0x000C_0062: "Closed Caption Select",
+	0x000C_0063: "VCR/TV",
+	0x000C_0064: "Broadcast Mode",
+	0x000C_0065: "Snapshot",
+	0x000C_0066: "Still",
+	0x000C_0067: "Picture-in-Picture Toggle",
+	0x000C_0068: "Picture-in-Picture Swap",
+	0x000C_0069: "Red Menu Button",
+	0x000C_006A: "Green Menu Button",
+	0x000C_006B: "Blue Menu Button",
+	0x000C_006C: "Yellow Menu Button",
+	0x000C_006D: "Aspect",
+	0x000C_006E: "3D Mode Select",
+	0x000C_006F: "Display Brightness Increment",
+	0x000C_0070: "Display Brightness Decrement",
+	0x000C_0071: "Display Brightness",
+	0x000C_0072: "Display Backlight Toggle",
+	0x000C_0073: "Display Set Brightness to Minimum",
+	0x000C_0074: "Display Set Brightness to Maximum",
+	0x000C_0075: "Display Set Auto Brightness",
+	0x000C_0076: "Camera Access Enabled",
+	0x000C_0077: "Camera Access Disabled",
+	0x000C_0078: "Camera Access Toggle",
+	0x000C_0079: "Keyboard Brightness Increment",
+	0x000C_007A: "Keyboard Brightness Decrement",
+	0x000C_007B: "Keyboard Backlight Set Level",
+	0x000C_007C: "Keyboard Backlight OOC",
+	0x000C_007D: "Keyboard Backlight Set Minimum",
+	0x000C_007E: "Keyboard Backlight Set Maximum",
+	0x000C_007F: "Keyboard Backlight Auto",
+	0x000C_0080: "Selection",
+	0x000C_0081: "Assign Selection",
+	0x000C_0082: "Mode Step",
+	0x000C_0083: "Recall Last",
+	0x000C_0084: "Enter Channel",
+	0x000C_0085: "Order Movie",
+	0x000C_0086: "Channel",
+	0x000C_0087: "Media Selection",
+	0x000C_0088: "Media Select Computer",
+	0x000C_0089: "Media Select TV",
+	0x000C_008A: "Media Select WWW",
+	0x000C_008B: "Media Select DVD",
+	0x000C_008C: "Media Select Telephone",
+	0x000C_008D: "Media Select Program Guide",
+	0x000C_008E: "Media Select Video Phone",
+	0x000C_008F: "Media Select Games",
+	0x000C_0090: "Media Select Messages",
+	0x000C_0091: "Media Select CD",
+	0x000C_0092: "Media Select VCR",
+	0x000C_0093: "Media Select Tuner",
+	0x000C_0094: "Quit",
+	0x000C_0095: "Help",
+	0x000C_0096: "Media Select Tape",
+	0x000C_0097: "Media Select Cable",
+	0x000C_0098: "Media Select Satellite",
+	0x000C_0099: "Media Select Security",
+	0x000C_009A: "Media Select Home",
+	0x000C_009B: "Media Select Call",
+	0x000C_009C: "Channel Increment",
+	0x000C_009D: "Channel Decrement",
+	0x000C_009E: "Media Select SAP",
+	0x000C_00A0: "VCR Plus",
+	0x000C_00A1: "Once",
+	0x000C_00A2: "Daily",
+	0x000C_00A3: "Weekly",
+	0x000C_00A4: "Monthly",
+	0x000C_00B0: "Play",
+	0x000C_00B1: "Pause",
+	0x000C_00B2: "Record",
+	0x000C_00B3: "Fast Forward",
+	0x000C_00B4: "Rewind",
+	0x000C_00B5: "Scan Next Track",
+	0x000C_00B6: "Scan Previous Track",
+	0x000C_00B7: "Stop",
+	0x000C_00B8: "Eject",
+	0x000C_00B9: "Random Play",
+	0x000C_00BA: "Select Disc",
+	0x000C_00BB: "Enter Disc",
+	0x000C_00BC: "Repeat",
+	0x000C_00BD: "Tracking",
+	0x000C_00BE: "Track Normal",
+	0x000C_00BF: "Slow Tracking",
+	0x000C_00C0: "Frame Forward",
+	0x000C_00C1: "Frame Back",
+	0x000C_00C2: "Mark",
+	0x000C_00C3: "Clear Mark",
+	0x000C_00C4: "Repeat From Mark",
+	0x000C_00C5: "Return To Mark",
+	0x000C_00C6: "Search Mark Forward",
+	0x000C_00C7: "Search Mark Backwards",
+	0x000C_00C8: "Counter Reset",
+	0x000C_00C9: "Show Counter",
+	0x000C_00CA: "Tracking Increment",
+	0x000C_00CB: "Tracking Decrement",
+	0x000C_00CC: "Stop/Eject",
+	0x000C_00CD: "Play/Pause",
+	0x000C_00CE: "Play/Skip",
+	0x000C_00CF: "Voice Command",
+	0x000C_00E0: "Volume",
+	0x000C_00E1: "Balance",
+	0x000C_00E2: "Mute",
+	0x000C_00E3: "Bass",
+	0x000C_00E4: "Treble",
+	0x000C_00E5: "Bass Boost",
+	0x000C_00E6: "Surround Mode",
+	0x000C_00E7: "Loudness",
+	0x000C_00E8: "MPX",
+	0x000C_00E9: "Volume Increment",
+	0x000C_00EA: "Volume Decrement",
+	0x000C_00F0: "Speed Select",
+	0x000C_00F1: "Playback Speed",
+	0x000C_00F2: "Standard Play",
+	0x000C_00F3: "Long Play",
+	0x000C_00F4: "Extended Play",
+	0x000C_00F5: "Slow",
+	0x000C_0100: "Fan Enable",
+	0x000C_0101: "Fan Speed",
+	0x000C_0102: "Light Enable",
+	0x000C_0103: "Light Illumination Level",
+	0x000C_0104: "Climate Control Enable",
+	0x000C_0105: "Room Temperature",
+	0x000C_0106: "Security Enable",
+	0x000C_0107: "Fire Alarm",
+	0x000C_0108: "Police Alarm",
+	0x000C_0109: "Proximity",
+	0x000C_010A: "Motion",
+	0x000C_010B: "Duress Alarm",
+	0x000C_010C: "Holdup Alarm",
+	0x000C_010D: "Medical Alarm",
+	0x000C_0150: "Balance Right",
+	0x000C_0151: "Balance Left",
+	0x000C_0152: "Bass Increment",
+	0x000C_0153: "Bass Decrement",
+	0x000C_0154: "Treble Increment",
+	0x000C_0155: "Treble Decrement",
+	0x000C_0160: "Speaker System",
+	0x000C_0161: "Channel Left",
+	0x000C_0162: "Channel Right",
+	0x000C_0163: "Channel Center",
+	0x000C_0164: "Channel Front",
+	0x000C_0165: "Channel Center Front",
+	0x000C_0166: "Channel Side",
+	0x000C_0167: "Channel Surround",
+	0x000C_0168: "Channel Low Frequency Enhancement",
+	0x000C_0169: "Channel Top",
+	0x000C_016A: "Channel Unknown",
+	0x000C_0170: "Sub-channel",
+	0x000C_0171: "Sub-channel Increment",
+	0x000C_0172: "Sub-channel Decrement",
+	0x000C_0173: "Alternate Audio Increment",
+	0x000C_0174: "Alternate Audio Decrement",
+	0x000C_0180: "Application Launch Buttons",
+	0x000C_0181: "AL Launch Button Configuration Tool",
+	0x000C_0182: "AL Programmable Button Configuration",
+	0x000C_0183: "AL Consumer Control Configuration",
+	0x000C_0184: "AL Word Processor",
+	0x000C_0185: "AL Text Editor",
+	0x000C_0186: "AL Spreadsheet",
+	0x000C_0187: "AL Graphics Editor",
+	0x000C_0188: "AL Presentation App",
+	0x000C_0189: "AL Database App",
+	0x000C_018A: "AL Email Reader",
+	0x000C_018B: "AL Newsreader",
+	0x000C_018C: "AL Voicemail",
+	0x000C_018D: "AL Contacts/Address Book",
+	0x000C_018E: "AL Calendar/Schedule",
+	0x000C_018F: "AL Task/Project Manager",
+	0x000C_0190: "AL Log/Journal/Timecard",
+	0x000C_0191: "AL Checkbook/Finance",
+	0x000C_0192: "AL Calculator",
+	0x000C_0193: "AL A/V Capture/Playback",
+	0x000C_0194: "AL Local Machine Browser",
+	0x000C_0195: "AL LAN/WAN Browser",
+	0x000C_0196: "AL Internet Browser",
+	0x000C_0197: "AL Remote Networking/ISP Connect",
+	0x000C_0198: "AL Network Conference",
+	0x000C_0199: "AL Network Chat",
+	0x000C_019A: "AL Telephony/Dialer",
+	0x000C_019B: "AL Logon",
+	0x000C_019C: "AL Logoff",
+	0x000C_019D: "AL Logon/Logoff",
+	0x000C_019E: "AL Terminal Lock/Screensaver",
+	0x000C_019F: "AL Control Panel",
+	0x000C_01A0: "AL Command Line Processor/Run",
+	0x000C_01A1: "AL Process/Task Manager",
+	0x000C_01A2: "AL Select Task/Application",
+	0x000C_01A3: "AL Next Task/Application",
+	0x000C_01A4: "AL Previous Task/Application",
+	0x000C_01A5: "AL Preemptive Halt Task/Application",
+	0x000C_01A6: "AL Integrated Help Center",
+	0x000C_01A7: "AL Documents",
+	0x000C_01A8: "AL Thesaurus",
+	0x000C_01A9: "AL Dictionary",
+	0x000C_01AA: "AL Desktop",
+	0x000C_01AB: "AL Spell Check",
+	0x000C_01AC: "AL Grammar Check",
+	0x000C_01AD: "AL Wireless Status",
+	0x000C_01AE: "AL Keyboard Layout",
+	0x000C_01AF: "AL Virus Protection",
+	0x000C_01B0: "AL Encryption",
+	0x000C_01B1: "AL Screen Saver",
+	0x000C_01B2: "AL Alarms",
+	0x000C_01B3: "AL Clock",
+	0x000C_01B4: "AL File Browser",
+	0x000C_01B5: "AL Power Status",
+	0x000C_01B6: "AL Image Browser",
+	0x000C_01B7: "AL Audio Browser",
+	0x000C_01B8: "AL Movie Browser",
+	0x000C_01B9: "AL Digital Rights Manager",
+	0x000C_01BA: "AL Digital Wallet",
+	0x000C_01BC: "AL Instant Messaging",
+	0x000C_01BD: "AL OEM Features/ Tips/Tutorial Browser",
+	0x000C_01BE: "AL OEM Help",
+	0x000C_01BF: "AL Online Community",
+	0x000C_01C0: "AL Entertainment Content Browser",
+	0x000C_01C1: "AL Online Shopping Browser",
+	0x000C_01C2: "AL SmartCard Information/Help",
+	0x000C_01C3: "AL Market Monitor/Finance Browser",
+	0x000C_01C4: "AL Customized Corporate News Browser",
+	0x000C_01C5: "AL Online Activity Browser",
+	0x000C_01C6: "AL Research/Search Browser",
+	0x000C_01C7: "AL Audio Player",
+	0x000C_01C8: "AL Message Status",
+	0x000C_01C9: "AL Contact Sync",
+	0x000C_01CA: "AL Navigation",
+	0x000C_01CB: "AL Context-aware Desktop Assistant",
+	0x000C_0200: "Generic GUI Application Controls",
+	0x000C_0201: "AC New",
+	0x000C_0202: "AC Open",
+	0x000C_0203: "AC Close",
+	0x000C_0204: "AC Exit",
+	0x000C_0205: "AC Maximize",
+	0x000C_0206: "AC Minimize",
+	0x000C_0207: "AC Save",
+	0x000C_0208: "AC Print",
+	0x000C_0209: "AC Properties",
+	0x000C_021A: "AC Undo",
+	0x000C_021B: "AC Copy",
+	0x000C_021C: "AC Cut",
+	0x000C_021D: "AC Paste",
+	0x000C_021E: "AC Select All",
+	0x000C_021F: "AC Find",
+	0x000C_0220: "AC Find and Replace",
+	0x000C_0221: "AC Search",
+	0x000C_0222: "AC Go To",
+	0x000C_0223: "AC Home",
+	0x000C_0224: "AC Back",
+	0x000C_0225: "AC Forward",
+	0x000C_0226: "AC Stop",
+	0x000C_0227: "AC Refresh",
+	0x000C_0228: "AC Previous Link",
+	0x000C_0229: "AC Next Link",
+	0x000C_022A: "AC Bookmarks",
+	0x000C_022B: "AC History",
+	0x000C_022C: "AC Subscriptions",
+	0x000C_022D: "AC Zoom In",
+	0x000C_022E: "AC Zoom Out",
+	0x000C_022F: "AC Zoom",
+	0x000C_0230: "AC Full Screen View",
+	0x000C_0231: "AC Normal View",
+	0x000C_0232: "AC View Toggle",
+	0x000C_0233: "AC Scroll Up",
+	0x000C_0234: "AC Scroll Down",
+	0x000C_0235: "AC Scroll",
+	0x000C_0236: "AC Pan Left",
+	0x000C_0237: "AC Pan Right",
+	0x000C_0238: "AC Pan",
+	0x000C_0239: "AC New Window",
+	0x000C_023A: "AC Tile Horizontally",
+	0x000C_023B: "AC Tile Vertically",
+	0x000C_023C: "AC Format",
+	0x000C_023D: "AC Edit",
+	0x000C_0279: "AC Redo/Repeat",
+	0x000C_029D: "AC Keyboard Layout Select",
+	0x000C_029F: "AC Desktop Show All Windows",
+	0x000C_02A2: "AC Desktop Show All Applications",
+
+	// Digitizers
+	0x000D_0001: "Digitizer",
+	0x000D_0002: "Pen",
+	0x000D_0003: "Light Pen",
+	0x000D_0004: "Touch Screen",
+	0x000D_0005: "Touch Pad",
+	0x000D_0006: "Whiteboard",
+	0x000D_0007: "Coordinate Measuring Machine",
+	0x000D_0008: "3D Digitizer",
+	0x000D_0009: "Stereo Plotter",
+	0x000D_000A: "Articulated Arm",
+	0x000D_000B: "Armature",
+	0x000D_000C: "Multiple Point Digitizer",
+	0x000D_000D: "Free Space Wand",
+	0x000D_000E: "Device Configuration",
+	0x000D_000F: "Capacitive Heat Map Digitizer",
+	0x000D_0020: "Stylus",
+	0x000D_0021: "Puck",
+	0x000D_0022: "Finger",
+	0x000D_0023: "Device Settings",
+	0x000D_0024: "Character Gesture",
+	0x000D_0030: "Tip Pressure",
+	0x000D_0031: "Barrel Pressure",
+	0x000D_0032: "In Range",
+	0x000D_0033: "Touch",
+	0x000D_0034: "Untouch",
+	0x000D_0035: "Tap",
+	0x000D_0036: "Quality",
+	0x000D_0037: "Data Valid",
+	0x000D_0038: "Transducer Index",
+	0x000D_0039: "Tablet Function Keys",
+	0x000D_003A: "Program Change Keys",
+	0x000D_003B: "Battery Strength",
+	0x000D_003C: "Invert",
+	0x000D_003D: "X Tilt",
+	0x000D_003E: "Y Tilt",
+	0x000D_003F: "Azimuth",
+	0x000D_0040: "Altitude",
+	0x000D_0041: "Twist",
+	0x000D_0042: "Tip Switch",
+	0x000D_0043: "Secondary Tip Switch",
+	0x000D_0044: "Barrel Switch",
+	0x000D_0045: "Eraser",
+	0x000D_0046: "Tablet Pick",
+	0x000D_0047: "Touch Valid",
+	0x000D_0048: "Width",
+	0x000D_0049: "Height",
+	0x000D_0051: "Contact Identifier",
+	0x000D_0052: "Device Mode",
+	0x000D_0053: "Device Identifier",
+	0x000D_0054: "Contact Count",
+	0x000D_0055: "Contact Count Maximum",
+	0x000D_0056: "Scan Time",
+	0x000D_0057: "Surface Switch",
+	0x000D_0058: "Button Switch",
+	0x000D_0059: "Pad Type",
+	0x000D_005A: "Secondary Barrel Switch",
+	0x000D_005B: "Transducer Serial Number",
+	0x000D_005C: "Preferred Color",
+	0x000D_005D: "Preferred Color is Locked",
+	0x000D_005E: "Preferred Line Width",
+	0x000D_005F: "Preferred Line Width is Locked",
+	0x000D_0060: "Latency Mode",
+	0x000D_0061: "Gesture Character Quality",
+	0x000D_0062: "Character Gesture Data Length",
+	0x000D_0063: "Character Gesture Data",
+	0x000D_0064: "Gesture Character Encoding",
+	0x000D_006A: "Capacitive Heat Map Protocol Vendor ID",
+	0x000D_006B: "Capacitive Heat Map Protocol Version",
+	0x000D_006C: "Capacitive Heat Map Frame Data",
+	0x000D_006D: "Gesture Character Enable",
+	0x000D_006E: "Transducer Serial Number Part 2",
+	0x000D_006F: "No Preferred Color",
+	0x000D_0091: "Transducer Vendor ID",
+	0x000D_0092: "Transducer Product ID",
+	0x000D_0093: "Device Supported Protocols",
+	0x000D_0094: "Transducer Supported Protocols",
+	0x000D_0095: "No Protocol",
+	0x000D_0096: "Wacom AES Protocol",
+	0x000D_0097: "USI Protocol",
+	0x000D_0098: "Microsoft Pen Protocol",
+
+	// Haptics
+	0x000E_0001: "Simple Haptic Controller",
+	0x000E_0010: "Waveform List",
+	0x000E_0011: "Duration List",
+	0x000E_0020: "Auto Trigger",
+	0x000E_0021: "Manual Trigger",
+	0x000E_0022: "Auto Trigger Associated Control",
+	0x000E_0023: "Intensity",
+	0x000E_0024: "Repeat Count",
+	0x000E_0025: "Retrigger Period",
+	0x000E_0026: "Waveform Vendor Page",
+	0x000E_0027: "Waveform Vendor ID",
+	0x000E_0028: "Waveform Cutoff Time",
+	0x000E_1001: "Waveform None",
+	0x000E_1002: "Waveform Stop",
+	0x000E_1003: "Waveform Click",
+	0x000E_1004: "Waveform Buzz Continuous",
+	0x000E_1005: "Waveform Rumble Continuous",
+	0x000E_1006: "Waveform Press",
+	0x000E_1007: "Waveform Release",
+
+	// Physical Input Device
+	0x000F_0001: "Physical Input Device",
+	0x000F_0020: "Normal",
+	0x000F_0021: "Set Effect Report",
+	0x000F_0022: "Effect Parameter Block Index",
+	0x000F_0023: "Parameter Block Offset",
+	0x000F_0024: "ROM Flag",
+	0x000F_0025: "Effect Type",
+	0x000F_0026: "ET Constant-Force",
+	0x000F_0027: "ET Ramp",
+	0x000F_0028: "ET Custom-Force",
+	0x000F_0030: "ET Square",
+	0x000F_0031: "ET Sine",
+	0x000F_0032: "ET Triangle",
+	0x000F_0033: "ET Sawtooth Up",
+	0x000F_0034: "ET Sawtooth Down",
+	0x000F_0040: "ET Spring",
+	0x000F_0041: "ET Damper",
+	0x000F_0042: "ET Inertia",
+	0x000F_0043: "ET Friction",
+
+	// Sensors
+	0x0020_0001: "Sensor",
+	0x0020_0010: "Biometric",
+	0x0020_0011: "Biometric: Human Presence",
+	0x0020_0012: "Biometric: Human Proximity",
+	0x0020_0013: "Biometric: Human Touch",
+	0x0020_0020: "Electrical",
+	0x0020_0030: "Environmental",
+	0x0020_0031: "Environmental: Atmospheric Pressure",
+	0x0020_0032: "Environmental: Humidity",
+	0x0020_0033: "Environmental: Temperature",
+	0x0020_0034: "Environmental: Wind Direction",
+	0x0020_0035: "Environmental: Wind Speed",
+	0x0020_0040: "Light",
+	0x0020_0041: "Light: Ambient Light",
+	0x0020_0042: "Light: Consumer Infrared",
+	0x0020_0050: "Location",
+	0x0020_0060: "Mechanical",
+	0x0020_0070: "Motion",
+	0x0020_0071: "Motion: Accelerometer 1D",
+	0x0020_0072: "Motion: Accelerometer 2D",
+	0x0020_0073: "Motion: Accelerometer 3D",
+	0x0020_0074: "Motion: Gyrometer 1D",
+	0x0020_0075: "Motion: Gyrometer 2D",
+	0x0020_0076: "Motion: Gyrometer 3D",
+	0x0020_0077: "Motion: Motion Detector",
+	0x0020_0078: "Motion: Speedometer",
+	0x0020_0079: "Motion: Accelerometer",
+	0x0020_007A: "Motion: Gyrometer",
+	0x0020_007B: "Motion: Gravity Vector",
+	0x0020_007C: "Motion: Linear Accelerometer",
+	0x0020_0080: "Orientation",
+	0x0020_0081: "Orientation: Compass 1D",
+	0x0020_0082: "Orientation: Compass 2D",
+	0x0020_0083: "Orientation: Compass 3D",
+	0x0020_0084: "Orientation: Inclinometer 1D",
+	0x0020_0085: "Orientation: Inclinometer 2D",
+	0x0020_0086: "Orientation: Inclinometer 3D",
+	0x0020_0087: "Orientation: Distance 1D",
+	0x0020_0088: "Orientation: Distance 2D",
+	0x0020_0089: "Orientation: Distance 3D",
+	0x0020_008A: "Orientation: Device Orientation",
+	0x0020_008B: "Orientation: Compass",
+	0x0020_008C: "Orientation: Inclinometer",
+	0x0020_008D: "Orientation: Distance",
+	0x0020_008E: "Orientation: Relative Orientation",
+	0x0020_008F: "Orientation: Simple Orientation",
+	0x0020_0090: "Scanner",
+	0x0020_00A0: "Time",
+	0x0020_00B0: "Personal Activity",
+	0x0020_00D0: "Gesture",
+	0x0020_00E0: "Other",
+	0x0020_00E1: "Other: Custom",
+	0x0020_00E2: "Other: Generic",
+	0x0020_00E3: "Other: Generic Enumerator",
+
+	// Monitor
+	0x0080_0001: "Monitor Control",
+	0x0080_0002: "EDID Information",
+	0x0080_0003: "VDIF Information",
+	0x0080_0004: "VESA Version",
+
+	// Power
+	0x0084_0001: "iName",
+	0x0084_0002: "Present Status",
+	0x0084_0003: "Changed Status",
+	0x0084_0004: "UPS",
+	0x0084_0005: "Power Supply",
+	0x0084_0010: "Battery System",
+	0x0084_0011: "Battery System ID",
+	0x0084_0012: "Battery",
+	0x0084_0013: "Battery ID",
+	0x0084_0014: "Charger",
+	0x0084_0015: "Charger ID",
+	0x0084_0016: "Power Converter",
+	0x0084_0017: "Power Converter ID",
+	0x0084_0018: "Outlet System",
+	0x0084_0019: "Outlet System ID",
+	0x0084_001A: "Input",
+	0x0084_001B: "Input ID",
+	0x0084_001C: "Output",
+	0x0084_001D: "Output ID",
+	0x0084_001E: "Flow",
+	0x0084_001F: "Flow ID",
+	0x0084_0020: "Outlet",
+	0x0084_0021: "Outlet ID",
+	0x0084_0022: "Gang",
+	0x0084_0023: "Gang ID",
+	0x0084_0024: "Power Summary",
+	0x0084_0025: "Power Summary ID",
+	0x0084_0030: "Voltage",
+	0x0084_0031: "Current",
+	0x0084_0032: "Frequency",
+	0x0084_0033: "Apparent Power",
+	0x0084_0034: "Active Power",
+	0x0084_0035: "Percent Load",
+	0x0084_0036: "Temperature",
+	0x0084_0037: "Humidity",
+	0x0084_0038: "Bad Count",
+	0x0084_0040: "Config Voltage",
+	0x0084_0041: "Config Current",
+	0x0084_0042: "Config Frequency",
+	0x0084_0043: "Config Apparent Power",
+	0x0084_0044: "Config Active Power",
+	0x0084_0045: "Config Percent Load",
+	0x0084_0046: "Config Temperature",
+	0x0084_0047: "Config Humidity",
+	0x0084_0050: "Switch On Control",
+	0x0084_0051: "Switch Off Control",
+	0x0084_0052: "Toggle Control",
+	0x0084_0053: "Low Voltage Transfer",
+	0x0084_0054: "High Voltage Transfer",
+	0x0084_0055: "Delay Before Reboot",
+	0x0084_0056: "Delay Before Startup",
+	0x0084_0057: "Delay Before Shutdown",
+	0x0084_0058: "Test",
+	0x0084_0059: "Module Reset",
+	0x0084_005A: "Audible Alarm Control",
+	0x0084_0060: "Present",
+	0x0084_0061: "Good",
+	0x0084_0062: "Internal Failure",
+	0x0084_0063: "Voltage Out Of Range",
+	0x0084_0064: "Frequency Out Of Range",
+	0x0084_0065: "Overload",
+	0x0084_0066: "Over Charged",
+	0x0084_0067: "Over Temperature",
+	0x0084_0068: "Shutdown Requested",
+	0x0084_0069: "Shutdown Imminent",
+	0x0084_006B: "Switch On/Off",
+	0x0084_006C: "Switchable",
+	0x0084_006D: "Used",
+	0x0084_006E: "Boost",
+	0x0084_006F: "Buck",
+	0x0084_0070: "Initialized",
+	0x0084_0071: "Tested",
+	0x0084_0072: "Awaiting Power",
+	0x0084_0073: "Communication Lost",
+	0x0084_00FD: "iManufacturer",
+	0x0084_00FE: "iProduct",
+	0x0084_00FF: "iSerialNumber",
+
+	// Battery System
+	0x0085_0001: "Smart Battery Battery Mode",
+	0x0085_0002: "Smart Battery Battery Status",
+	0x0085_0003: "Smart Battery Alarm Warning",
+	0x0085_0004: "Smart Battery Charger Mode",
+	0x0085_0005: "Smart Battery Charger Status",
+	0x0085_0006: "Smart Battery Charger Spec Info",
+	0x0085_0007: "Smart Battery Selector State",
+	0x0085_0008: "Smart Battery Selector Presets",
+	0x0085_0009: "Smart Battery Selector Info",
+	0x0085_0010: "Optional Mfg Function 1",
+	0x0085_0011: "Optional Mfg Function 2",
+	0x0085_0012: "Optional Mfg Function 3",
+	0x0085_0013: "Optional Mfg Function 4",
+	0x0085_0014: "Optional Mfg Function 5",
+	0x0085_0015: "Connection to SMBus",
+	0x0085_0016: "Output Connection",
+	0x0085_0017: "Charger Connection",
+	0x0085_0018: "Battery Insertion",
+	0x0085_0019: "Use Next",
+	0x0085_001A: "OK to Use",
+	0x0085_001B: "Battery Supported",
+	0x0085_001C: "Selector Revision",
+	0x0085_001D: "Charging Indicator",
+	0x0085_0028: "Manufacturer Access",
+	0x0085_0029: "Remaining Capacity Limit",
+	0x0085_002A: "Remaining Time Limit",
+	0x0085_002B: "At Rate",
+	0x0085_002C: "Capacity Mode",
+	0x0085_002D: "Broadcast To Charger",
+	0x0085_002E: "Primary Battery",
+	0x0085_002F: "Charge Controller",
+	0x0085_0040: "Terminate Charge",
+	0x0085_0041: "Terminate Discharge",
+	0x0085_0042: "Below Remaining Capacity Limit",
+	0x0085_0043: "Remaining Time Limit Expired",
+	0x0085_0044: "Charging",
+	0x0085_0045: "Discharging",
+	0x0085_0046: "Fully Charged",
+	0x0085_0047: "Fully Discharged",
+	0x0085_0048: "Conditioning Flag",
+	0x0085_0049: "At Rate OK",
+	0x0085_004A: "Smart Battery Error Code",
+	0x0085_004B: "Need Replacement",
+	0x0085_0060: "At Rate Time To Full",
+	0x0085_0061: "At Rate Time To Empty",
+	0x0085_0062: "Average Current",
+	0x0085_0063: "Max Error",
+	0x0085_0064: "Relative State Of Charge",
+	0x0085_0065: "Absolute State Of Charge",
+	0x0085_0066: "Remaining Capacity",
+	0x0085_0067: "Full Charge Capacity",
+	0x0085_0068: "Run Time To Empty",
+	0x0085_0069: "Average Time To Empty",
+	0x0085_006A: "Average Time To Full",
+	0x0085_006B: "Cycle Count",
+	0x0085_0080: "Battery Pack Model Level",
+	0x0085_0081: "Internal Charge Controller",
+	0x0085_0082: "Primary Battery Support",
+	0x0085_0083: "Design Capacity",
+	0x0085_0084: "Specification Info",
+	0x0085_0085: "Manufacture Date",
+	0x0085_0086: "Serial Number",
+	0x0085_0087: "iManufacturer Name",
+	0x0085_0088: "iDevice Name",
+	0x0085_0089: "iDevice Chemistry",
+	0x0085_008A: "Manufacturer Data",
+	0x0085_008B: "Rechargeable",
+	0x0085_008C: "Warning Capacity Limit",
+	0x0085_008D: "Capacity Granularity 1",
+	0x0085_008E: "Capacity Granularity 2",
+	0x0085_008F: "iOEM Information",
+	0x0085_00C0: "Inhibit Charge",
+	0x0085_00C1: "Enable Polling",
+	0x0085_00C2: "Reset To Zero",
+	0x0085_00D0: "AC Present",
+	0x0085_00D1: "Battery Present",
+	0x0085_00D2: "Power Fail",
+	0x0085_00D3: "Alarm Inhibited",
+	0x0085_00D4: "Thermistor Under Range",
+	0x0085_00D5: "Thermistor Hot",
+	0x0085_00D6: "Thermistor Cold",
+	0x0085_00D7: "Thermistor Over Range",
+	0x0085_00D8: "Voltage Out Of Range",
+	0x0085_00D9: "Current Out Of Range",
+	0x0085_00DA: "Current Not Regulated",
+	0x0085_00DB: "Voltage Not Regulated",
+	0x0085_00DC: "Master Mode",
+	0x0085_00F0: "Charger Selector Support",
+	0x0085_00F1: "Charger Spec",
+	0x0085_00F2: "Level 2",
+	0x0085_00F3: "Level 3",
+
+	// FIDO Alliance
+	0xF1D0_0001: "U2F Authenticator Device",
+	0xF1D0_0020: "Input Report Data",
+	0xF1D0_0021: "Output Report Data",
+}
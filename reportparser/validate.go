@@ -0,0 +1,353 @@
+package reportparser
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"slices"
+)
+
+type Severity uint8
+
+const (
+	// SeverityWarning marks a descriptor that parses but that hosts may
+	// interpret differently or that HID 1.11 discourages.
+	SeverityWarning Severity = iota
+	// SeverityError marks a violation of HID 1.11.
+	SeverityError
+)
+
+func (s Severity) String() string {
+	switch s {
+	case SeverityWarning:
+		return "warning"
+	case SeverityError:
+		return "error"
+	default:
+		return fmt.Sprintf("Severity(%d)", uint8(s))
+	}
+}
+
+// Diagnostic is a problem found by Validate.
+type Diagnostic struct {
+	Severity Severity
+	// Offset is the byte offset of the offending item, or the descriptor
+	// length for problems found at its end.
+	Offset  int
+	Message string
+}
+
+func (d Diagnostic) String() string {
+	return fmt.Sprintf("offset %d: %v: %s", d.Offset, d.Severity, d.Message)
+}
+
+// Validate checks a report descriptor against the rules of HID 1.11 and
+// returns its diagnostics ordered by offset. Unlike Parse, it does not stop
+// at the first problem. A descriptor without diagnostics of SeverityError
+// can be parsed by Parse.
+func Validate(b []byte) []Diagnostic {
+	v := &validator{firstNumbered: -1, firstUnnumbered: -1, reportBits: make(map[[2]uint8]int64)}
+	v.resetLocal()
+
+	for i := 0; i < len(b); {
+		item, next, err := parseItem(b, i)
+		if errors.Is(err, ErrTruncatedItem) {
+			v.errorf(i, "truncated item")
+			break
+		}
+		switch {
+		case errors.Is(err, ErrInvalidReportID):
+			v.errorf(i, "Report ID %d is greater than 255", parseUintValue(b[i+1:next]))
+		case err != nil:
+			v.errorf(i, "reserved item with prefix 0x%02X", b[i])
+		default:
+			v.item(i, item)
+		}
+		i = next
+	}
+	v.end(len(b))
+
+	slices.SortStableFunc(v.diagnostics, func(a, b Diagnostic) int {
+		return cmp.Compare(a.Offset, b.Offset)
+	})
+	return v.diagnostics
+}
+
+// validationGlobal is the global state together with the global items that
+// were declared.
+type validationGlobal struct {
+	globalState
+	hasLogicalMinimum, hasLogicalMaximum bool
+	hasReportSize, hasReportCount        bool
+}
+
+// validationLocal tracks the local items that apply to the next main item.
+type validationLocal struct {
+	// offset is the offset of the first local item, or -1.
+	offset int
+	usages int
+
+	usageMinimum, usageMaximum             uint32
+	usageMinimumOffset, usageMaximumOffset int
+
+	delimiterOffset int
+}
+
+type validator struct {
+	diagnostics []Diagnostic
+
+	global validationGlobal
+	stack  []validationGlobal
+	// pushOffsets holds the offsets of the Push items on the stack.
+	pushOffsets []int
+	local       validationLocal
+	collections []CollectionItemType
+
+	firstNumbered, firstUnnumbered int
+	// reportBits holds the data bits declared so far for each report type
+	// and report ID, or more than the maximum once a report is too long.
+	reportBits map[[2]uint8]int64
+}
+
+func (v *validator) errorf(offset int, format string, args ...any) {
+	v.diagnostics = append(v.diagnostics, Diagnostic{SeverityError, offset, fmt.Sprintf(format, args...)})
+}
+
+func (v *validator) warnf(offset int, format string, args ...any) {
+	v.diagnostics = append(v.diagnostics, Diagnostic{SeverityWarning, offset, fmt.Sprintf(format, args...)})
+}
+
+func (v *validator) item(offset int, item any) {
+	switch e := item.(type) {
+	case UsagePage:
+		v.global.usagePage = e.Value()
+	case LogicalMinimum:
+		v.global.logicalMinimum, v.global.hasLogicalMinimum = e.Value(), true
+	case LogicalMaximum:
+		v.global.logicalMaximum, v.global.hasLogicalMaximum = e.Value(), true
+	case PhysicalMinimum:
+		v.global.physicalMinimum = e.Value()
+	case PhysicalMaximum:
+		v.global.physicalMaximum = e.Value()
+	case Unit:
+		v.global.unit = e
+	case UnitExponent:
+		v.global.unitExponent = e.Value()
+	case ReportID:
+		if e == 0 {
+			v.errorf(offset, "Report ID 0 is reserved")
+		}
+		v.global.reportID = uint8(e)
+	case ReportSize:
+		v.global.reportSize, v.global.hasReportSize = uint32(e), true
+	case ReportCount:
+		v.global.reportCount, v.global.hasReportCount = uint32(e), true
+	case Push:
+		v.stack = append(v.stack, v.global)
+		v.pushOffsets = append(v.pushOffsets, offset)
+	case Pop:
+		if len(v.stack) == 0 {
+			v.errorf(offset, "Pop without matching Push")
+			break
+		}
+		v.global = v.stack[len(v.stack)-1]
+		v.stack = v.stack[:len(v.stack)-1]
+		v.pushOffsets = v.pushOffsets[:len(v.pushOffsets)-1]
+	case Usage:
+		v.localItem(offset)
+		v.local.usages++
+	case UsageMinimum:
+		v.localItem(offset)
+		if v.local.usageMinimumOffset >= 0 {
+			v.errorf(v.local.usageMinimumOffset, "Usage Minimum without Usage Maximum")
+		}
+		v.local.usageMinimum, v.local.usageMinimumOffset = e.Value(), offset
+		v.checkUsageRange()
+	case UsageMaximum:
+		v.localItem(offset)
+		if v.local.usageMaximumOffset >= 0 {
+			v.errorf(v.local.usageMaximumOffset, "Usage Maximum without Usage Minimum")
+		}
+		v.local.usageMaximum, v.local.usageMaximumOffset = e.Value(), offset
+		v.checkUsageRange()
+	case DesignatorIndex, DesignatorMinimum, DesignatorMaximum, StringIndex, StringMinimum, StringMaximum:
+		v.localItem(offset)
+	case Delimiter:
+		v.localItem(offset)
+		switch {
+		case e == 1 && v.local.delimiterOffset >= 0:
+			v.errorf(offset, "nested Delimiter")
+		case e == 1:
+			v.local.delimiterOffset = offset
+		case e == 0 && v.local.delimiterOffset < 0:
+			v.errorf(offset, "closing Delimiter without opening Delimiter")
+		case e == 0:
+			v.local.delimiterOffset = -1
+		default:
+			v.errorf(offset, "Delimiter %d is neither open (1) nor close (0)", e)
+		}
+	case Input:
+		v.mainItem(offset, ReportTypeInput, e.Name(), uint32(e), 1<<7)
+	case Output:
+		v.mainItem(offset, ReportTypeOutput, e.Name(), uint32(e), 0)
+	case Feature:
+		v.mainItem(offset, ReportTypeFeature, e.Name(), uint32(e), 0)
+	case Collection:
+		v.checkLocal()
+		if len(v.collections) == 0 && e.Value() != CollectionItemTypeApplication {
+			v.warnf(offset, "top-level collection is not an Application collection")
+		}
+		if e.Value() == CollectionItemTypeApplication && v.local.usages == 0 && v.local.usageMinimumOffset < 0 {
+			v.warnf(offset, "Application collection without a Usage")
+		}
+		v.collections = append(v.collections, e.Value())
+		v.resetLocal()
+	case EndCollection:
+		if v.local.offset >= 0 {
+			v.warnf(v.local.offset, "local items before End Collection apply to no main item")
+		}
+		v.checkLocal()
+		if len(v.collections) == 0 {
+			v.errorf(offset, "End Collection without matching Collection")
+		} else {
+			v.collections = v.collections[:len(v.collections)-1]
+		}
+		v.resetLocal()
+	case LongItem:
+		v.warnf(offset, "long item 0x%02X; HID 1.11 defines no long items", e.LongTag)
+	}
+}
+
+func (v *validator) resetLocal() {
+	v.local = validationLocal{
+		offset:             -1,
+		usageMinimumOffset: -1,
+		usageMaximumOffset: -1,
+		delimiterOffset:    -1,
+	}
+}
+
+func (v *validator) localItem(offset int) {
+	if v.local.offset < 0 {
+		v.local.offset = offset
+	}
+}
+
+// checkUsageRange checks a complete Usage Minimum and Maximum pair.
+func (v *validator) checkUsageRange() {
+	if v.local.usageMinimumOffset < 0 || v.local.usageMaximumOffset < 0 {
+		return
+	}
+	minimum, maximum := v.local.usageMinimum, v.local.usageMaximum
+	offset := max(v.local.usageMinimumOffset, v.local.usageMaximumOffset)
+	switch {
+	case minimum>>16 != 0 && maximum>>16 != 0 && minimum>>16 != maximum>>16:
+		v.errorf(offset, "Usage Minimum 0x%X and Usage Maximum 0x%X are on different usage pages", minimum, maximum)
+	case uint16(minimum) > uint16(maximum):
+		v.errorf(offset, "Usage Minimum 0x%X is greater than Usage Maximum 0x%X", minimum, maximum)
+	}
+	v.local.usages++
+	v.local.usageMinimumOffset, v.local.usageMaximumOffset = -1, -1
+}
+
+// checkLocal reports local items that a main item cannot use.
+func (v *validator) checkLocal() {
+	if v.local.usageMinimumOffset >= 0 {
+		v.errorf(v.local.usageMinimumOffset, "Usage Minimum without Usage Maximum")
+	}
+	if v.local.usageMaximumOffset >= 0 {
+		v.errorf(v.local.usageMaximumOffset, "Usage Maximum without Usage Minimum")
+	}
+	if v.local.delimiterOffset >= 0 {
+		v.errorf(v.local.delimiterOffset, "Delimiter is not closed")
+	}
+}
+
+// mainItem checks an Input, Output or Feature item. reserved holds the flag
+// bits that are reserved for the item besides bits 9 and up.
+func (v *validator) mainItem(offset int, typ ReportType, name string, flags, reserved uint32) {
+	v.checkLocal()
+	defer v.resetLocal()
+
+	if len(v.collections) == 0 {
+		v.warnf(offset, "%s item outside of any collection", name)
+	}
+	if bits := flags & (reserved | ^uint32(1<<9-1)); bits != 0 {
+		v.warnf(offset, "%s item sets reserved bits 0x%X", name, bits)
+	}
+	if !v.global.hasReportSize {
+		v.errorf(offset, "%s item without Report Size", name)
+	} else if size := v.global.reportSize; size == 0 || size > 32 {
+		v.errorf(offset, "%s item has Report Size %d; Report Size must be between 1 and 32", name, size)
+	}
+	if !v.global.hasReportCount {
+		v.errorf(offset, "%s item without Report Count", name)
+	}
+	v.checkReportLength(offset, typ, name)
+
+	if v.global.reportID != 0 {
+		if v.firstNumbered < 0 {
+			v.firstNumbered = offset
+		}
+	} else if v.firstUnnumbered < 0 {
+		v.firstUnnumbered = offset
+	}
+
+	if flags&uint32(InputFlagConstant) != 0 {
+		return
+	}
+	if v.local.usages == 0 {
+		v.warnf(offset, "%s item without a Usage", name)
+	}
+	if !v.global.hasLogicalMinimum || !v.global.hasLogicalMaximum {
+		v.errorf(offset, "%s item without Logical Minimum and Logical Maximum", name)
+	} else if v.global.logicalMaximum < v.global.logicalMinimum {
+		hint := ""
+		if v.global.logicalMaximum < 0 && v.global.logicalMinimum >= 0 {
+			hint = "; Logical Maximum is signed and needs a larger item size for positive values"
+		}
+		v.errorf(offset, "%s item has Logical Maximum %d less than Logical Minimum %d%s",
+			name, v.global.logicalMaximum, v.global.logicalMinimum, hint)
+	}
+	physicalMinimum, physicalMaximum := v.global.physicalMinimum, v.global.physicalMaximum
+	if (physicalMinimum != 0 || physicalMaximum != 0) && physicalMaximum < physicalMinimum {
+		v.errorf(offset, "%s item has Physical Maximum %d less than Physical Minimum %d",
+			name, physicalMaximum, physicalMinimum)
+	}
+}
+
+// checkReportLength adds the item to the length of its report and reports
+// the item that makes the report longer than the maximum report length.
+func (v *validator) checkReportLength(offset int, typ ReportType, name string) {
+	id := v.global.reportID
+	key := [2]uint8{uint8(typ), id}
+	limit := maxReportBitLength(id)
+	bits := v.reportBits[key]
+	if bits > limit {
+		return
+	}
+	// The product of two 32-bit values fits in a uint64 but not an int64.
+	length := uint64(v.global.reportSize) * uint64(v.global.reportCount)
+	if length > uint64(limit-bits) {
+		v.errorf(offset, "%s item makes report %d longer than the maximum of %d bytes", name, id, maxReportLength)
+		v.reportBits[key] = limit + 1
+		return
+	}
+	v.reportBits[key] = bits + int64(length)
+}
+
+func (v *validator) end(offset int) {
+	if v.local.offset >= 0 {
+		v.warnf(v.local.offset, "local items at the end of the descriptor apply to no main item")
+	}
+	v.checkLocal()
+	if n := len(v.collections); n > 0 {
+		v.errorf(offset, "%d Collection items without matching End Collection", n)
+	}
+	for _, pushOffset := range v.pushOffsets {
+		v.warnf(pushOffset, "Push without matching Pop")
+	}
+	if v.firstNumbered >= 0 && v.firstUnnumbered >= 0 {
+		v.errorf(v.firstUnnumbered, "main item without Report ID in a descriptor with numbered reports")
+	}
+}
@@ -0,0 +1,196 @@
+package reportparser
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestValidateValidDescriptors(t *testing.T) {
+	for name, descriptor := range map[string][]byte{
+		"fido":  fidoDescriptor,
+		"mouse": mouseDescriptor,
+		"led":   ledDescriptor,
+	} {
+		if diagnostics := Validate(descriptor); len(diagnostics) != 0 {
+			t.Errorf("Validate(%s) = %v, want no diagnostics", name, diagnostics)
+		}
+	}
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name       string
+		descriptor []byte
+		want       Diagnostic
+	}{
+		{
+			name:       "unbalanced End Collection",
+			descriptor: []byte{0x05, 0x01, 0x09, 0x02, 0xa1, 0x01, 0xc0, 0xc0},
+			want:       Diagnostic{SeverityError, 7, "End Collection without matching Collection"},
+		},
+		{
+			name:       "unclosed collection",
+			descriptor: []byte{0x05, 0x01, 0x09, 0x02, 0xa1, 0x01},
+			want:       Diagnostic{SeverityError, 6, "1 Collection items without matching End Collection"},
+		},
+		{
+			name: "missing Report Size",
+			descriptor: []byte{
+				0x06, 0x00, 0xff, 0x09, 0x01, 0xa1, 0x01,
+				0x09, 0x02, 0x15, 0x00, 0x25, 0x01, 0x95, 0x08,
+				0x81, 0x02, // Input (Variable) at offset 15
+				0xc0,
+			},
+			want: Diagnostic{SeverityError, 15, "Input item without Report Size"},
+		},
+		{
+			name: "mixed numbered and unnumbered reports",
+			descriptor: []byte{
+				0x06, 0x00, 0xff, 0x09, 0x01, 0xa1, 0x01,
+				0x09, 0x02, 0x15, 0x00, 0x25, 0x01, 0x75, 0x08, 0x95, 0x01,
+				0x81, 0x02, // Input without Report ID at offset 17
+				0x85, 0x01,
+				0x09, 0x03, 0x91, 0x02,
+				0xc0,
+			},
+			want: Diagnostic{SeverityError, 17, "main item without Report ID in a descriptor with numbered reports"},
+		},
+		{
+			name: "logical maximum below minimum",
+			descriptor: []byte{
+				0x06, 0x00, 0xff, 0x09, 0x01, 0xa1, 0x01,
+				0x09, 0x02, 0x15, 0x00, 0x25, 0xff, 0x75, 0x08, 0x95, 0x01,
+				0x81, 0x02, // Input at offset 17
+				0xc0,
+			},
+			want: Diagnostic{SeverityError, 17, "Input item has Logical Maximum -1 less than Logical Minimum 0; Logical Maximum is signed and needs a larger item size for positive values"},
+		},
+		{
+			name:       "reserved Report ID",
+			descriptor: []byte{0x85, 0x00},
+			want:       Diagnostic{SeverityError, 0, "Report ID 0 is reserved"},
+		},
+		{
+			name: "zero Report Size",
+			descriptor: []byte{
+				0x06, 0x00, 0xff, 0x09, 0x01, 0xa1, 0x01,
+				0x09, 0x02, 0x15, 0x00, 0x25, 0x01, 0x75, 0x00, 0x95, 0x01,
+				0x81, 0x02, // Input at offset 17
+				0xc0,
+			},
+			want: Diagnostic{SeverityError, 17, "Input item has Report Size 0; Report Size must be between 1 and 32"},
+		},
+		{
+			name:       "overflowing Report Size and Count",
+			descriptor: overflowDescriptor,
+			want:       Diagnostic{SeverityError, 22, "Input item has Report Size 4294967295; Report Size must be between 1 and 32"},
+		},
+		{
+			name:       "overflowing report length",
+			descriptor: overflowDescriptor,
+			want:       Diagnostic{SeverityError, 22, "Input item makes report 0 longer than the maximum of 16384 bytes"},
+		},
+		{
+			name: "report longer than the maximum in total",
+			descriptor: []byte{
+				0x06, 0x00, 0xff, 0x09, 0x01, 0xa1, 0x01, 0x85, 0x01,
+				0x09, 0x02, 0x15, 0x00, 0x25, 0x01, 0x75, 0x20, 0x96, 0x00, 0x08,
+				0xb1, 0x02, // Feature at offset 20
+				0xb1, 0x02, // Feature at offset 22
+				0xc0,
+			},
+			want: Diagnostic{SeverityError, 22, "Feature item makes report 1 longer than the maximum of 16384 bytes"},
+		},
+		{
+			name:       "16-bit Report ID",
+			descriptor: []byte{0x86, 0x01, 0x01},
+			want:       Diagnostic{SeverityError, 0, "Report ID 257 is greater than 255"},
+		},
+		{
+			name:       "Pop without Push",
+			descriptor: []byte{0x05, 0x01, 0xb4},
+			want:       Diagnostic{SeverityError, 2, "Pop without matching Push"},
+		},
+		{
+			name:       "unpaired Usage Minimum",
+			descriptor: []byte{0x05, 0x09, 0x19, 0x01, 0xa1, 0x00, 0xc0},
+			want:       Diagnostic{SeverityError, 2, "Usage Minimum without Usage Maximum"},
+		},
+		{
+			name:       "reversed usage range",
+			descriptor: []byte{0x05, 0x09, 0x19, 0x05, 0x29, 0x01},
+			want:       Diagnostic{SeverityError, 4, "Usage Minimum 0x5 is greater than Usage Maximum 0x1"},
+		},
+		{
+			name:       "nested Delimiter",
+			descriptor: []byte{0xa9, 0x01, 0xa9, 0x01},
+			want:       Diagnostic{SeverityError, 2, "nested Delimiter"},
+		},
+		{
+			name:       "truncated item",
+			descriptor: []byte{0x05, 0x01, 0x26, 0xff},
+			want:       Diagnostic{SeverityError, 2, "truncated item"},
+		},
+		{
+			name:       "reserved item",
+			descriptor: []byte{0x05, 0x01, 0xf0},
+			want:       Diagnostic{SeverityError, 2, "reserved item with prefix 0xF0"},
+		},
+		{
+			name:       "top-level physical collection",
+			descriptor: []byte{0x05, 0x01, 0x09, 0x01, 0xa1, 0x00, 0xc0},
+			want:       Diagnostic{SeverityWarning, 4, "top-level collection is not an Application collection"},
+		},
+		{
+			name:       "Push without Pop",
+			descriptor: []byte{0xa4},
+			want:       Diagnostic{SeverityWarning, 0, "Push without matching Pop"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			diagnostics := Validate(test.descriptor)
+			for _, d := range diagnostics {
+				if d == test.want {
+					return
+				}
+			}
+			t.Fatalf("Validate() = %v, want to include %v", diagnostics, test.want)
+		})
+	}
+}
+
+func TestValidateErrorsMatchParse(t *testing.T) {
+	for _, descriptor := range [][]byte{
+		fidoDescriptor,
+		{0x05, 0x01, 0xa1, 0x01},
+		{0x05, 0x01, 0xc0},
+		{0xb4},
+		{0x26, 0xff},
+		{0x86, 0x01, 0x01},
+		overflowDescriptor,
+	} {
+		hasError := false
+		for _, d := range Validate(descriptor) {
+			hasError = hasError || d.Severity == SeverityError
+		}
+		d, err := Parse(descriptor)
+		if err != nil {
+			if !hasError {
+				t.Errorf("Parse(% x) error = %v, but Validate reported no errors", descriptor, err)
+			}
+			continue
+		}
+		if _, err := d.Layout(); err != nil && !hasError {
+			t.Errorf("Layout(% x) error = %v, but Validate reported no errors", descriptor, err)
+		}
+	}
+}
+
+func TestDiagnosticString(t *testing.T) {
+	d := Diagnostic{SeverityWarning, 4, "long item 0xF0; HID 1.11 defines no long items"}
+	if got := d.String(); !strings.HasPrefix(got, "offset 4: warning: long item") {
+		t.Fatalf("String() = %q", got)
+	}
+}
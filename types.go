@@ -13,8 +13,23 @@ type DeviceInfo struct {
 	InterfaceNbr   int    // USB Interface Number
 	InstanceID     string
 	ParentDeviceID string
+	BusType        BusType // Bus the Device is Attached to
 }
 
+// BusType identifies the bus that a device is attached to. It is empty when
+// the platform does not report the bus or reports a bus without a constant.
+type BusType string
+
+const (
+	BusUSB       BusType = "usb"
+	BusBluetooth BusType = "bluetooth"
+	BusI2C       BusType = "i2c"
+	BusSPI       BusType = "spi"
+	// BusVirtual is a device created by software, such as a Linux uhid
+	// device.
+	BusVirtual BusType = "virtual"
+)
+
 type ioResult struct {
 	n    int
 	data []byte
@@ -36,6 +51,7 @@ type enumerateOptions struct {
 	interfaceNbr   *int
 	instanceID     *string
 	parentDeviceID *string
+	busType        *BusType
 }
 
 func WithPath(path string) EnumerateOption {
@@ -110,6 +126,15 @@ func WithParentDeviceID(parentDeviceID string) EnumerateOption {
 	}
 }
 
+// WithBusType selects devices attached to busType. On Linux without sysfs,
+// DeviceInfo.BusType is left empty, because the bus that a device declares
+// through the hidraw ioctls would let uhid devices pass as physical ones.
+func WithBusType(busType BusType) EnumerateOption {
+	return func(opts *enumerateOptions) {
+		opts.busType = &busType
+	}
+}
+
 func newEnumerateOptions(options []EnumerateOption) enumerateOptions {
 	var opts enumerateOptions
 	for _, option := range options {
@@ -157,5 +182,8 @@ func (opts enumerateOptions) match(info *DeviceInfo) bool {
 	if opts.parentDeviceID != nil && info.ParentDeviceID != *opts.parentDeviceID {
 		return false
 	}
+	if opts.busType != nil && info.BusType != *opts.busType {
+		return false
+	}
 	return true
 }
@@ -16,7 +16,9 @@ const (
 	kIOHIDReportTypeFeature ioHIDReportType = 2
 
 	kIOReturnSuccess       ioReturn = 0
+	kIOReturnNoDevice      ioReturn = -536870208 // 0xe00002c0
 	kIOReturnNotPrivileged ioReturn = -536870207 // 0xe00002c1
+	kIOReturnNotAttached   ioReturn = -536870184 // 0xe00002d8
 	kIOReturnNotPermitted  ioReturn = -536870174 // 0xe00002e2
 	kCFRunLoopRunFinished           = 1
 
@@ -57,6 +59,9 @@ type Device struct {
 	runLoop    uintptr
 	cbID       uintptr
 	closeMu    sync.Mutex
+	featureMu  ioLock
 	removeOnce sync.Once
 	closed     bool
+	operations sync.WaitGroup
+	done       deviceDone
 }
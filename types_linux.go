@@ -3,10 +3,15 @@ package hid
 import (
 	"os"
 	"sync"
+	"sync/atomic"
 )
 
 type Device struct {
-	file    *os.File
-	readMu  sync.Mutex
-	writeMu sync.Mutex
+	file      *os.File
+	removal   *os.File
+	readMu    sync.Mutex
+	writeMu   ioLock
+	featureMu ioLock
+	closed    atomic.Bool
+	done      deviceDone
 }
@@ -14,6 +14,13 @@ type Device struct {
 	readTimeout             uint32
 	readMu                  sync.Mutex
 	writeMu                 sync.Mutex
+	featureMu               ioLock
+	closeMu                 sync.RWMutex
+	closed                  bool
+	operations              sync.WaitGroup
 	closeOnce               sync.Once
 	closeErr                error
+	notify                  windows.Handle
+	cbID                    uintptr
+	done                    deviceDone
 }